@@ -0,0 +1,96 @@
+package vali
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// CardBrandError is returned by the `creditcard:<brands>` checker for valid
+// card numbers of a brand other than the allowed ones (or of no known brand).
+type CardBrandError struct {
+	// Brand is the brand detected from the card number, if any, i.e. "amex".
+	Brand string
+
+	// Allowed are the brands the card number was expected to be of.
+	Allowed []string
+}
+
+func (e *CardBrandError) Error() string {
+	if e.Brand == "" {
+		return fmt.Sprintf("card brand is unknown, not one of %v", e.Allowed)
+	}
+
+	return fmt.Sprintf("card brand %s is not one of %v", e.Brand, e.Allowed)
+}
+
+// cardBrand holds the IIN (issuer identification number) prefix ranges
+// and the allowed lengths of the card numbers of a brand.
+type cardBrand struct {
+	name           string
+	prefixes       [][2]string
+	minLen, maxLen int
+}
+
+// cardBrands are the card brands known to [CardBrand], in detection order:
+// the narrower ranges (i.e. the Discover ones within the UnionPay 62) first.
+var cardBrands = []cardBrand{
+	{"visa", [][2]string{{"4", "4"}}, 13, 19},
+	{"mastercard", [][2]string{{"51", "55"}, {"2221", "2720"}}, 16, 16},
+	{"amex", [][2]string{{"34", "34"}, {"37", "37"}}, 15, 15},
+	{"discover", [][2]string{{"6011", "6011"}, {"644", "649"}, {"65", "65"}, {"622126", "622925"}}, 16, 19},
+	{"diners", [][2]string{{"300", "305"}, {"36", "36"}, {"38", "39"}}, 14, 19},
+	{"jcb", [][2]string{{"3528", "3589"}}, 16, 19},
+	{"unionpay", [][2]string{{"62", "62"}}, 16, 19},
+}
+
+// CardBrand returns the brand of the card number s (spaces and dashes are
+// ignored), detected from its IIN prefix and length, i.e. "visa", "mastercard",
+// "amex", "discover", "diners", "jcb" or "unionpay", or "" if unknown.
+// It does not validate the number itself (see the `creditcard` checker).
+func CardBrand(s string) string {
+	s = strings.ReplaceAll(strings.ReplaceAll(s, " ", ""), "-", "")
+
+	for _, b := range cardBrands {
+		if len(s) < b.minLen || len(s) > b.maxLen {
+			continue
+		}
+
+		for _, r := range b.prefixes {
+			lo, _ := strconv.Atoi(r[0])
+			hi, _ := strconv.Atoi(r[1])
+
+			if p, err := strconv.Atoi(s[:len(r[0])]); err == nil && p >= lo && p <= hi {
+				return b.name
+			}
+		}
+	}
+
+	return ""
+}
+
+// creditCardOf checks that the value is a valid credit card number of one
+// of the given brands, i.e. `creditcard:visa|mastercard` (see [CardBrand]).
+func (v *Validator) creditCardOf(args string) (c Checker, err error) {
+	brands := v.ParseArgs(args)
+
+	for _, brand := range brands {
+		if !slices.ContainsFunc(cardBrands, func(b cardBrand) bool { return b.name == brand }) {
+			return nil, fmt.Errorf("unknown card brand %q", brand)
+		}
+	}
+
+	return func(val reflect.Value) (err error) {
+		if err = creditCard(val); err != nil {
+			return
+		}
+
+		if brand := CardBrand(String(val)); !slices.Contains(brands, brand) {
+			return &CardBrandError{Brand: brand, Allowed: brands}
+		}
+
+		return
+	}, nil
+}
@@ -0,0 +1,70 @@
+package vali
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestCardBrand(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		num, exp string
+	}{
+		{"4111111111111111", "visa"},
+		{"4222222222222", "visa"},
+		{"5555555555554444", "mastercard"},
+		{"2223003122003222", "mastercard"},
+		{"2721000000000000", ""},
+		{"3782 822463 10005", "amex"},
+		{"6011-1111-1111-1117", "discover"},
+		{"6221260000000000", "discover"},
+		{"6200000000000005", "unionpay"},
+		{"30569309025904", "diners"},
+		{"3530111333300000", "jcb"},
+		{"9999999999999995", ""},
+		{"41", ""},
+		{"", ""},
+	}
+
+	for _, tc := range testCases {
+		if act := CardBrand(tc.num); act != tc.exp {
+			t.Fatalf("Expected %q got %q for %q", tc.exp, act, tc.num)
+		}
+	}
+}
+
+func TestCreditCardOf(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		val   any
+		tag   string
+		exp   string
+		brand string
+	}{
+		{"4111111111111111", "creditcard:visa", "", ""},
+		{"5555555555554444", "creditcard:visa|mastercard", "", ""},
+		{"378282246310005", "creditcard:visa|mastercard", "creditcard check failed: card brand amex is not one of [visa mastercard]", "amex"},
+		{"9999999999999995", "creditcard:visa", "creditcard check failed: card brand is unknown, not one of [visa]", ""},
+		{"4111111111111112", "creditcard:visa", `creditcard check failed: "4111111111111112" is not valid according to the Luhn algorithm`, ""},
+		{4111111111111111, "creditcard:visa", "", ""},
+		{"", "creditcard:visa", "", ""},
+		{"4111111111111111", "creditcard:amex|bogus", `invalid checker creditcard:amex|bogus: unknown card brand "bogus"`, ""},
+		{"378282246310005", "creditcard", "", ""},
+	}
+
+	for _, tc := range testCases {
+		err := Validate(tc.val, tc.tag)
+		if act := errString(err); act != tc.exp {
+			t.Fatalf("Expected %q got %q for %v", tc.exp, act, tc.val)
+		}
+
+		cbe := (*CardBrandError)(nil)
+		if ok := errors.As(err, &cbe); ok && (cbe.Brand != tc.brand || !slices.Contains(cbe.Allowed, "visa")) ||
+			!ok && tc.brand != "" {
+			t.Fatalf("Expected brand %q got %v", tc.brand, cbe)
+		}
+	}
+}
@@ -1,18 +1,28 @@
 package vali
 
 import (
+	"bytes"
 	"cmp"
+	"encoding/csv"
 	"encoding/json"
+	"encoding/xml"
 	"errors"
 	"fmt"
+	"io"
+	"math"
+	"math/big"
 	"net"
 	"net/mail"
+	"net/netip"
 	"net/url"
 	"reflect"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
+	"unicode/utf8"
 )
 
 type expOutcome int
@@ -26,22 +36,63 @@ const (
 
 const rgbRange = `(?:2(?:5[0-5]|[0-4]\d)|1\d\d|[1-9]?\d)`
 
+// The nil struct policies, see [Validator.NilStructs].
+const (
+	NilStructSkip NilStructPolicy = iota
+	NilStructZero
+	NilStructError
+)
+
+// nilStructPolicies maps the nil_struct modifier arguments to policies.
+var nilStructPolicies = map[string]NilStructPolicy{
+	"skip":  NilStructSkip,
+	"zero":  NilStructZero,
+	"error": NilStructError,
+}
+
 // Possible errors.
 var (
 	ErrCheckFailed    = errors.New("check failed")
 	ErrRequired       = errors.New("value missing")
 	ErrInvalidChecker = errors.New("invalid checker")
 	ErrInvalidCmp     = errors.New("invalid comparison")
+	ErrFrozen         = errors.New("validator is frozen")
+	ErrTooManyErrors  = errors.New("too many errors")
+	ErrInvalidArg     = errors.New("invalid argument")
+)
+
+// The [ErrRequired] failures tell apart the values missing altogether (i.e. nil
+// pointers, slices or maps, or absent JSON keys) from the present, but empty
+// (zero) ones, as the remedies differ. Both read the same as [ErrRequired],
+// unless [Validator.RequiredDetail] is set.
+var (
+	ErrAbsent error = &requiredError{"absent"}
+	ErrEmpty  error = &requiredError{"empty"}
 )
 
 //nolint:errcheck,lll // well covered with tests
 var (
 	npiRx          = regexp.MustCompile(`^\d{10}$`)
+	leiRx          = regexp.MustCompile(`^[0-9A-Z]{18}[0-9]{2}$`)
+	mod97Rx        = regexp.MustCompile(`^[0-9A-Z]+$`)
+	ninoRx         = regexp.MustCompile(`^[A-CEGHJ-PR-TW-Z][A-CEGHJ-NPR-TW-Z]\d{6}[A-D]$`)
+	sinRx          = regexp.MustCompile(`^\d{9}$`)
+	dunsRx         = regexp.MustCompile(`^\d{9}$`)
+	gitSHARx       = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+	cveRx          = regexp.MustCompile(`^CVE-(\d{4})-(\d{4}|[1-9]\d{4,})$`)
+	bech32Rx       = regexp.MustCompile(`^([!-~]{1,83})1([qpzry9x8gf2tvdw0s3jn54khce6mua7l]{6,})$`)
+	oidRx          = regexp.MustCompile(`^([0-2])\.(0|[1-9]\d*)(\.(0|[1-9]\d*))*$`)
+	gitSCPRx       = regexp.MustCompile(`^[A-Za-z0-9._-]+@[A-Za-z0-9.-]+:/?[^/\s]\S*$`)
+	htmlTagRx      = regexp.MustCompile(`<[a-zA-Z!/?][^>]*>`)
+	numberRx       = regexp.MustCompile(`^[+-]?(?:\d+\.?\d*|\.\d+)(?:[eE][+-]?\d+)?$`)
+	htmlEntityRx   = regexp.MustCompile(`^&(?:[a-zA-Z][a-zA-Z0-9]*|#[0-9]+|#[xX][0-9a-fA-F]+);`)
 	uuid, _        = Regex(`(?i)^[0-9a-f]{8}-?[0-9a-f]{4}-?[0-9a-f]{4}-?[0-9a-f]{4}-?[0-9a-f]{12}$`)
 	mongoID, _     = Regex(`(?i)^[0-9a-f]{24}$`)
 	hexadecimal, _ = Regex(`(?i)^[0-9a-f]+$`)
 	base64, _      = Regex(`(?i)^(?:[a-z0-9+/]{4})*(?:[a-z0-9+/]{2}==|[a-z0-9+/]{3}=)?$`)
+	base58, _      = Regex(`^[1-9A-HJ-NP-Za-km-z]+$`)
 	domain, _      = Regex(`(?i)^([a-z0-9]([a-z0-9\-]{0,61}[a-z0-9])?\.)+[a-z]{2,}$`)
+	dnsLabel, _    = Regex(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
 	ssn, _         = Regex(`^(0(0[1-9]|[1-9]\d)|[1-5]\d\d|6([0-5]\d|6[0-5]|6[7-9]|[7-9]\d)|[7-8]\d\d)-(0[1-9]|[1-9]\d)-(000[1-9]|00[1-9]\d|0[1-9]\d\d|[1-9]\d\d\d)$`)
 	alpha, _       = Regex(`(?i)^[a-z]*$`)
 	alphaNum, _    = Regex(`(?i)^[a-z0-9]*$`)
@@ -58,7 +109,7 @@ var expLabel = map[expOutcome]string{
 }
 
 func email(v reflect.Value) (err error) {
-	s := fmt.Sprint(Interface(v))
+	s := String(v)
 	if _, err = mail.ParseAddress(s); err != nil {
 		return fmt.Errorf("%q is not a valid email address", s)
 	}
@@ -67,7 +118,7 @@ func email(v reflect.Value) (err error) {
 }
 
 func urL(v reflect.Value) (err error) {
-	s := fmt.Sprint(Interface(v))
+	s := String(v)
 
 	u, err := url.Parse(s)
 	if err != nil {
@@ -82,7 +133,7 @@ func urL(v reflect.Value) (err error) {
 }
 
 func ip(v reflect.Value) (err error) {
-	if s := fmt.Sprint(Interface(v)); net.ParseIP(s) == nil {
+	if _, s, ok := ipOf(v); !ok {
 		return fmt.Errorf("%q is not a valid IP address", s)
 	}
 
@@ -90,8 +141,7 @@ func ip(v reflect.Value) (err error) {
 }
 
 func ipv4(v reflect.Value) (err error) {
-	s := fmt.Sprint(Interface(v))
-	if ip := net.ParseIP(s); ip == nil || ip.To4() == nil {
+	if ip, s, ok := ipOf(v); !ok || !(ip.Is4() || ip.Is4In6()) {
 		return fmt.Errorf("%q is not a valid IPv4 address", s)
 	}
 
@@ -99,16 +149,54 @@ func ipv4(v reflect.Value) (err error) {
 }
 
 func ipv6(v reflect.Value) (err error) {
-	s := fmt.Sprint(Interface(v))
-	if ip := net.ParseIP(s); ip == nil || ip.To4() != nil {
+	if ip, s, ok := ipOf(v); !ok || !ip.Is6() || ip.Is4In6() {
 		return fmt.Errorf("%q is not a valid IPv6 address", s)
 	}
 
 	return
 }
 
+// ipOf extracts the IP address from [netip.Addr] and [net.IP] values
+// directly and by parsing the string representation of anything else.
+func ipOf(v reflect.Value) (ip netip.Addr, s string, ok bool) {
+	switch x := Interface(v).(type) {
+	case netip.Addr:
+		return x, x.String(), x.IsValid()
+	case net.IP:
+		ip, ok = netip.AddrFromSlice(x)
+		return ip, x.String(), ok
+	}
+
+	s = String(v)
+	if x := net.ParseIP(s); x != nil {
+		ip, ok = netip.AddrFromSlice(x)
+	}
+
+	return
+}
+
+func cidr(v reflect.Value) (err error) {
+	switch x := Interface(v).(type) {
+	case netip.Prefix:
+		if !x.IsValid() {
+			return fmt.Errorf("%q is not a valid CIDR", x)
+		}
+	case net.IPNet:
+		if x.IP == nil || x.Mask == nil {
+			return fmt.Errorf("%q is not a valid CIDR", x)
+		}
+	default:
+		s := String(v)
+		if _, _, err = net.ParseCIDR(s); err != nil {
+			return fmt.Errorf("%q is not a valid CIDR", s)
+		}
+	}
+
+	return
+}
+
 func mac(v reflect.Value) (err error) {
-	s := fmt.Sprint(Interface(v))
+	s := String(v)
 	if _, err = net.ParseMAC(s); err != nil {
 		return fmt.Errorf("%q is not a valid MAC address", s)
 	}
@@ -117,7 +205,7 @@ func mac(v reflect.Value) (err error) {
 }
 
 func isbn(v reflect.Value) (err error) {
-	switch s := strings.ReplaceAll(fmt.Sprint(Interface(v)), "-", ""); len(s) {
+	switch s := strings.ReplaceAll(String(v), "-", ""); len(s) {
 	case 10:
 		return validateISBN10(s)
 	case 13:
@@ -186,7 +274,7 @@ func validateISBN13(s string) (err error) {
 }
 
 func boolean(v reflect.Value) (err error) {
-	switch s := fmt.Sprint(Interface(v)); strings.ToLower(s) {
+	switch s := String(v); strings.ToLower(s) {
 	case "1", "t", "true", "yes", "y", "on":
 		return
 	case "0", "f", "false", "no", "n", "off":
@@ -197,7 +285,7 @@ func boolean(v reflect.Value) (err error) {
 }
 
 func creditCard(v reflect.Value) (err error) {
-	s := fmt.Sprint(Interface(v))
+	s := String(v)
 	s = strings.ReplaceAll(s, " ", "")
 	s = strings.ReplaceAll(s, "-", "")
 
@@ -216,19 +304,187 @@ func creditCard(v reflect.Value) (err error) {
 
 func jsoN(v reflect.Value) (err error) {
 	var (
-		s  = fmt.Sprint(Interface(v))
+		b  = bytesOf(v)
 		js any
 	)
 
-	if err = json.Unmarshal([]byte(s), &js); err != nil {
-		return fmt.Errorf("%q is not valid JSON: %w", s, err)
+	if err = json.Unmarshal(b, &js); err != nil {
+		return fmt.Errorf("%q is not valid JSON: %w", b, err)
+	}
+
+	return
+}
+
+// GeoJSON validates that the value holds a structurally valid GeoJSON
+// geometry (RFC 7946), i.e. a known type with coordinates of the right arity.
+func geoJSON(v reflect.Value) (err error) {
+	b := bytesOf(v)
+
+	if err = validateGeometry(b); err != nil {
+		return fmt.Errorf("%q is not a valid GeoJSON geometry: %w", b, err)
+	}
+
+	return
+}
+
+func validateGeometry(b []byte) (err error) {
+	var g struct {
+		Type        string            `json:"type"`
+		Coordinates json.RawMessage   `json:"coordinates"`
+		Geometries  []json.RawMessage `json:"geometries"`
+	}
+
+	if err = json.Unmarshal(b, &g); err != nil {
+		return
+	}
+
+	if g.Type == "GeometryCollection" {
+		if g.Geometries == nil {
+			return errors.New("missing geometries")
+		}
+
+		for _, gg := range g.Geometries {
+			if err = validateGeometry(gg); err != nil {
+				return
+			}
+		}
+
+		return
+	}
+
+	if g.Coordinates == nil {
+		return errors.New("missing coordinates")
+	}
+
+	switch g.Type {
+	case "Point":
+		var c []float64
+		if err = json.Unmarshal(g.Coordinates, &c); err == nil {
+			err = validatePositions(1, c)
+		}
+	case "MultiPoint":
+		var c [][]float64
+		if err = json.Unmarshal(g.Coordinates, &c); err == nil {
+			err = validatePositions(0, c...)
+		}
+	case "LineString":
+		var c [][]float64
+		if err = json.Unmarshal(g.Coordinates, &c); err == nil {
+			err = validatePositions(2, c...)
+		}
+	case "MultiLineString":
+		var c [][][]float64
+		if err = json.Unmarshal(g.Coordinates, &c); err == nil {
+			for _, ls := range c {
+				if err = validatePositions(2, ls...); err != nil {
+					break
+				}
+			}
+		}
+	case "Polygon":
+		var c [][][]float64
+		if err = json.Unmarshal(g.Coordinates, &c); err == nil {
+			err = validatePolygon(c)
+		}
+	case "MultiPolygon":
+		var c [][][][]float64
+		if err = json.Unmarshal(g.Coordinates, &c); err == nil {
+			for _, p := range c {
+				if err = validatePolygon(p); err != nil {
+					break
+				}
+			}
+		}
+	default:
+		return fmt.Errorf("unknown geometry type %q", g.Type)
+	}
+
+	return
+}
+
+func validatePolygon(rings [][][]float64) (err error) {
+	for _, ring := range rings {
+		if err = validatePositions(4, ring...); err != nil {
+			return
+		}
+
+		if !slices.Equal(ring[0], ring[len(ring)-1]) {
+			return errors.New("linear ring is not closed")
+		}
+	}
+
+	return
+}
+
+func validatePositions(minCount int, px ...[]float64) (err error) {
+	if len(px) < minCount {
+		return fmt.Errorf("need at least %d positions, got %d", minCount, len(px))
+	}
+
+	for _, p := range px {
+		if len(p) < 2 {
+			return fmt.Errorf("position %v needs at least 2 elements", p)
+		}
+	}
+
+	return
+}
+
+// XML validates that the value is a well-formed XML document with a single root element.
+func xmL(v reflect.Value) (err error) {
+	b := bytesOf(v)
+
+	if err = validateXML(b); err != nil {
+		return fmt.Errorf("%q is not valid XML: %w", b, err)
 	}
 
 	return
 }
 
+func validateXML(b []byte) (err error) {
+	var (
+		d     = xml.NewDecoder(bytes.NewReader(b))
+		depth int
+		roots int
+		tok   xml.Token
+	)
+
+	for {
+		if tok, err = d.Token(); err != nil {
+			break
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if depth == 0 {
+				if roots++; roots > 1 {
+					return errors.New("multiple root elements")
+				}
+			}
+
+			depth++
+		case xml.EndElement:
+			depth--
+		case xml.CharData:
+			if depth == 0 && len(bytes.TrimSpace(t)) > 0 {
+				return errors.New("text outside of root element")
+			}
+		}
+	}
+
+	if !errors.Is(err, io.EOF) {
+		return
+	}
+
+	if roots == 0 {
+		return errors.New("missing root element")
+	}
+
+	return nil
+}
+
 func ascii(v reflect.Value) (err error) {
-	s := fmt.Sprint(Interface(v))
+	s := String(v)
 	for i, r := range s {
 		if r > unicode.MaxASCII {
 			return fmt.Errorf("%q contains non-ASCII character %q at position %d", s, r, i)
@@ -239,7 +495,7 @@ func ascii(v reflect.Value) (err error) {
 }
 
 func lowercase(v reflect.Value) (err error) {
-	s := fmt.Sprint(Interface(v))
+	s := String(v)
 	for i, r := range s {
 		if unicode.IsUpper(r) {
 			return fmt.Errorf("%q contains uppercase character %q at position %d", s, r, i)
@@ -250,7 +506,7 @@ func lowercase(v reflect.Value) (err error) {
 }
 
 func uppercase(v reflect.Value) (err error) {
-	s := fmt.Sprint(Interface(v))
+	s := String(v)
 	for i, r := range s {
 		if unicode.IsLower(r) {
 			return fmt.Errorf("%q contains lowercase character %q at position %d", s, r, i)
@@ -260,6 +516,34 @@ func uppercase(v reflect.Value) (err error) {
 	return
 }
 
+func noHTML(v reflect.Value) (err error) {
+	s := String(v)
+	if tag := htmlTagRx.FindString(s); tag != "" {
+		return fmt.Errorf("%q contains HTML tag %q", s, tag)
+	}
+
+	return
+}
+
+func htmlEscaped(v reflect.Value) (err error) {
+	s := String(v)
+	for i, r := range s {
+		switch r {
+		case '<', '>':
+		case '&':
+			if htmlEntityRx.MatchString(s[i:]) {
+				continue
+			}
+		default:
+			continue
+		}
+
+		return fmt.Errorf("%q contains unescaped %q at position %d", s, r, i)
+	}
+
+	return
+}
+
 // Luhn validates strings or numbers using the Luhn algorithm.
 func luhn(v reflect.Value) (err error) {
 	var s string //nolint:varnamelen // ok
@@ -274,7 +558,7 @@ func luhn(v reflect.Value) (err error) {
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		s = strconv.FormatUint(v.Uint(), 10)
 	default:
-		s = fmt.Sprint(Interface(v))
+		s = String(v)
 	}
 
 	s = strings.ReplaceAll(strings.ReplaceAll(s, " ", ""), "-", "")
@@ -310,7 +594,7 @@ func luhn(v reflect.Value) (err error) {
 
 // NPI validates if a string is a valid National Provider Identifier.
 func npi(v reflect.Value) (err error) {
-	s := fmt.Sprint(Interface(v))
+	s := String(v)
 	if !npiRx.MatchString(s) {
 		return fmt.Errorf("%q is not a valid NPI", s)
 	}
@@ -319,134 +603,565 @@ func npi(v reflect.Value) (err error) {
 	return luhn(reflect.ValueOf("80840" + s))
 }
 
-func required(v reflect.Value) (err error) {
-	if isZero(v) {
-		return ErrRequired
+// nino validates a UK National Insurance number, i.e. "QQ 12 34 56 C" (the
+// spaces are ignored): two prefix letters, none of the never allocated ones,
+// six digits and an A to D suffix.
+func nino(v reflect.Value) (err error) {
+	s := String(v)
+	n := strings.ReplaceAll(s, " ", "")
+
+	if !ninoRx.MatchString(n) || slices.Contains([]string{"BG", "GB", "KN", "NK", "NT", "TN", "ZZ"}, n[:2]) {
+		return fmt.Errorf("%q is not a valid National Insurance number", s)
 	}
 
 	return
 }
 
-// Regex allows you to easily create regex-based checkers.
-func Regex(arg string) (c Checker, err error) {
-	rx, err := regexp.Compile(arg)
-	if err != nil {
-		return
+// sin validates a Canadian Social Insurance Number: 9 digits, optionally
+// grouped by spaces or dashes (i.e. "046 454 286"), passing the Luhn check.
+func sin(v reflect.Value) (err error) {
+	s := String(v)
+	if n := strings.NewReplacer(" ", "", "-", "").Replace(s); !sinRx.MatchString(n) || luhn(reflect.ValueOf(n)) != nil {
+		return fmt.Errorf("%q is not a valid SIN", s)
+	}
+
+	return
+}
+
+// lei validates a Legal Entity Identifier: 18 alphanumerics followed by the
+// 2 ISO 17442 check digits (ISO 7064 mod 97-10, the same as for IBANs).
+func lei(v reflect.Value) (err error) {
+	s := String(v)
+	if !leiRx.MatchString(s) {
+		return fmt.Errorf("%q is not a valid LEI", s)
+	}
+
+	if mod97Rem(s) != 1 {
+		return fmt.Errorf("%q has invalid LEI check digits", s)
+	}
+
+	return
+}
+
+// duns validates a D-U-N-S number: 9 digits, optionally grouped by dashes
+// (i.e. "15-048-3782"), which are ignored.
+func duns(v reflect.Value) (err error) {
+	if s := String(v); !dunsRx.MatchString(strings.ReplaceAll(s, "-", "")) {
+		return fmt.Errorf("%q is not a valid DUNS number", s)
+	}
+
+	return
+}
+
+// gitSHA validates a (lowercase, hex) git commit hash,
+// either abbreviated (7 digits at least) or full (SHA-1).
+func gitSHA(v reflect.Value) (err error) {
+	if s := String(v); !gitSHARx.MatchString(s) {
+		return fmt.Errorf("%q is not a valid git commit SHA", s)
+	}
+
+	return
+}
+
+// gitSHAOf validates a full git commit hash, i.e. `git_sha:strict`:
+// 40 (SHA-1) or 64 (SHA-256) lowercase hex digits, not abbreviated.
+func gitSHAOf(arg string) (c Checker, err error) {
+	if arg != "strict" {
+		return nil, fmt.Errorf("unknown mode %q", arg)
 	}
 
 	return func(v reflect.Value) (err error) {
-		act := fmt.Sprint(Interface(v))
-		if rx.MatchString(act) {
-			return
+		if s := String(v); len(s) != 40 && len(s) != 64 || strings.Trim(s, "0123456789abcdef") != "" {
+			return fmt.Errorf("%q is not a full git commit SHA", s)
 		}
 
-		return fmt.Errorf("%q does not match %s", act, arg)
+		return
 	}, nil
 }
 
-// Eq checks numbers for being == `arg` and things with a `len()`
-// (`array`, `chan`, `map`, `slice`, `string`) for having len == `arg`.
-func Eq(arg string) (c Checker, err error) {
-	return sizeCmp(arg, expEq)
-}
+// gitURL validates a git remote URL, either scp-like (i.e.
+// "git@github.com:owner/repo.git") or an ssh or https one.
+func gitURL(v reflect.Value) (err error) {
+	s := String(v)
+	if gitSCPRx.MatchString(s) {
+		return
+	}
 
-// Ne checks numbers for being != `arg` and things with a `len()`
-// (`array`, `chan`, `map`, `slice`, `string`) for having len != `arg`.
-func Ne(arg string) (c Checker, err error) {
-	return sizeCmp(arg, expNotEq)
-}
+	if u, err := url.Parse(s); err == nil && (u.Scheme == "https" || u.Scheme == "ssh") &&
+		u.Host != "" && strings.Trim(u.Path, "/") != "" {
+		return nil
+	}
 
-// Min checks numbers for being at least `arg` and things with a `len()`
-// (`array`, `chan`, `map`, `slice`, `string`) for having len at least `arg`.
-func Min(arg string) (c Checker, err error) {
-	return sizeCmp(arg, expMore)
+	return fmt.Errorf("%q is not a valid git URL", s)
 }
 
-// Max checks numbers for being at most `arg` and things with a `len()`
-// (`array`, `chan`, `map`, `slice`, `string`) for having len at most `arg`.
-func Max(arg string) (c Checker, err error) {
-	return sizeCmp(arg, expLess)
+// cve validates a CVE identifier, i.e. "CVE-2024-3094": a year, between the
+// first CVE one (1999) and the next, followed by a sequence number of 4 digits
+// at least (with no leading zeros, when longer).
+func cve(v reflect.Value) (err error) {
+	s := String(v)
+
+	m := cveRx.FindStringSubmatch(s)
+	if m == nil {
+		return fmt.Errorf("%q is not a valid CVE identifier", s)
+	}
+
+	if year, _ := strconv.Atoi(m[1]); year < 1999 || year > time.Now().Year()+1 {
+		return fmt.Errorf("%q has an invalid CVE year", s)
+	}
+
+	return
 }
 
-//nolint:nakedret,gocognit,funlen,cyclop // ok
-func sizeCmp(arg string, exp expOutcome) (c Checker, err error) {
-	label := expLabel[exp]
+// oid validates a (dotted) ASN.1 object identifier, i.e. "1.3.6.1.4.1":
+// two arcs at least, the first being 0, 1 or 2 and, under 0 or 1, the
+// second being 39 at most, as per X.660.
+func oid(v reflect.Value) (err error) {
+	s := String(v)
 
-	return func(v reflect.Value) (err error) {
-		defer func() {
-			if r := recover(); r != nil {
-				if v, ok := r.(error); ok {
-					err = v
-				} else {
-					err = errors.New(fmt.Sprint(r))
-				}
-			}
-		}()
+	m := oidRx.FindStringSubmatch(s)
+	if m == nil {
+		return fmt.Errorf("%q is not a valid OID", s)
+	}
 
-		switch {
-		case v.CanInt():
-			var x int64
+	if second, err := strconv.Atoi(m[2]); m[1] != "2" && (err != nil || second > 39) {
+		return fmt.Errorf("%q is not a valid OID (second arc over 39)", s)
+	}
 
-			if x, err = strconv.ParseInt(arg, 10, 64); err != nil {
-				return
-			}
+	return
+}
 
-			if y := v.Int(); cmp2(y, x, exp) {
-				return fmt.Errorf("%d is %s %d", y, label, x)
-			}
-		case v.CanUint():
-			var x uint64
+// bech32 validates a Bech32 (BIP 173) or Bech32m (BIP 350) string, i.e.
+// "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq": a human readable part, the
+// "1" separator and the data, ending in a 6 characters checksum. It is either
+// lowercase or uppercase, but not mixed. The 90 characters limit of BIP 173 is
+// not enforced, so that longer strings (i.e. Lightning invoices) pass too.
+func bech32(v reflect.Value) (err error) {
+	s := String(v)
+	if strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return fmt.Errorf("%q is not a valid bech32 string (mixed case)", s)
+	}
 
-			if x, err = strconv.ParseUint(arg, 10, 64); err != nil {
-				return
-			}
+	m := bech32Rx.FindStringSubmatch(strings.ToLower(s))
+	if m == nil {
+		return fmt.Errorf("%q is not a valid bech32 string", s)
+	}
 
-			if y := v.Uint(); cmp2(y, x, exp) {
-				return fmt.Errorf("%d is %s %d", y, label, x)
-			}
-		case v.CanFloat():
-			var x float64
+	if c := bech32Polymod(m[1], m[2]); c != 1 && c != 0x2bc830a3 {
+		return fmt.Errorf("%q has an invalid bech32 checksum", s)
+	}
 
-			switch v.Kind() { //nolint:exhaustive // only floats can float
-			case reflect.Float32:
-				if x, err = strconv.ParseFloat(arg, 32); err != nil {
-					return
-				}
+	return
+}
 
-				vv := float32(v.Float())
-				if cmp2(vv, float32(x), exp) {
-					return fmt.Errorf("%.0f is %s %.0f", vv, label, x)
-				}
-			case reflect.Float64:
-				if x, err = strconv.ParseFloat(arg, 64); err != nil {
-					return
-				}
+// bech32Polymod returns the BCH checksum of the human readable part hrp
+// and the data, which is 1 for Bech32 and 0x2bc830a3 for Bech32m.
+func bech32Polymod(hrp, data string) (chk uint32) {
+	gen := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk = 1
 
-				vv := v.Float()
-				if cmp2(vv, x, exp) {
-					return fmt.Errorf("%.0f is %s %.0f", vv, label, x)
-				}
-			}
-		default:
-			var x int //nolint:varnamelen // ok
+	step := func(x uint32) {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ x
 
-			if x, err = strconv.Atoi(arg); err != nil {
-				return
+		for i, g := range gen {
+			if top>>i&1 == 1 {
+				chk ^= g
 			}
+		}
+	}
 
-			for v.Kind() == reflect.Pointer {
-				if v.IsNil() {
-					return
-				}
+	for _, r := range hrp {
+		step(uint32(r) >> 5)
+	}
 
-				v = v.Elem()
-			}
+	step(0)
 
-			if v.Kind() == reflect.Invalid {
-				return nil
-			}
+	for _, r := range hrp {
+		step(uint32(r) & 31)
+	}
 
-			switch v.Kind() {
+	for _, r := range data {
+		step(uint32(strings.IndexRune("qpzry9x8gf2tvdw0s3jn54khce6mua7l", r)))
+	}
+
+	return
+}
+
+// The USPS codes of the US states (and DC), territories and military "states".
+var (
+	usStates = strings.Fields(`AL AK AZ AR CA CO CT DE DC FL GA HI ID IL IN IA KS KY LA ME MD MA MI MN MS MO MT NE NV
+		NH NJ NM NY NC ND OH OK OR PA RI SC SD TN TX UT VT VA WA WV WI WY`)
+	usTerritories = strings.Fields("AS GU MP PR VI UM FM MH PW")
+	usMilitary    = strings.Fields("AA AE AP")
+)
+
+// usState validates the (uppercase) USPS code of a US state or of DC.
+func usState(v reflect.Value) (err error) {
+	if s := String(v); !slices.Contains(usStates, s) {
+		return fmt.Errorf("%q is not a US state code", s)
+	}
+
+	return
+}
+
+// usStateOf validates the USPS code of a US state (or DC) and, as per its
+// args, of a territory or a military one, i.e. `us_state:territories|military`.
+func (v *Validator) usStateOf(args string) (c Checker, err error) {
+	codes := slices.Clone(usStates)
+
+	for _, arg := range v.ParseArgs(args) {
+		switch arg {
+		case "territories":
+			codes = append(codes, usTerritories...)
+		case "military":
+			codes = append(codes, usMilitary...)
+		default:
+			return nil, fmt.Errorf("unknown option %q", arg)
+		}
+	}
+
+	return func(val reflect.Value) (err error) {
+		if s := String(val); !slices.Contains(codes, s) {
+			return fmt.Errorf("%q is not a US state code", s)
+		}
+
+		return
+	}, nil
+}
+
+// callingCodes are the country calling codes assigned by the ITU (E.164),
+// including the non geographic ones (i.e. 800, the international freephone).
+var callingCodes = strings.Fields(`
+		1 20 211 212 213 216 218 220 221 222 223 224 225 226 227 228 229 230 231 232 233 234 235 236 237 238 239
+		240 241 242 243 244 245 246 247 248 249 250 251 252 253 254 255 256 257 258 260 261 262 263 264 265 266
+		267 268 269 27 290 291 297 298 299 30 31 32 33 34 350 351 352 353 354 355 356 357 358 359 36 370 371 372
+		373 374 375 376 377 378 379 380 381 382 383 385 386 387 389 39 40 41 420 421 423 43 44 45 46 47 48 49
+		500 501 502 503 504 505 506 507 508 509 51 52 53 54 55 56 57 58 590 591 592 593 594 595 596 597 598 599
+		60 61 62 63 64 65 66 670 672 673 674 675 676 677 678 679 680 681 682 683 685 686 687 688 689 690 691 692
+		7 800 808 81 82 84 86 850 852 853 855 856 870 880 881 882 883 886 888 90 91 92 93 94 95 960 961 962 963
+		964 965 966 967 968 970 971 972 973 974 975 976 977 979 98 992 993 994 995 996 998`)
+
+// callingCode validates an international dialing prefix (country calling
+// code), with or without the leading "+", i.e. "+40", "1" or "44".
+func callingCode(v reflect.Value) (err error) {
+	if s := String(v); !slices.Contains(callingCodes, strings.TrimPrefix(s, "+")) {
+		return fmt.Errorf("%q is not a valid country calling code", s)
+	}
+
+	return
+}
+
+// mod97 validates an ISO 7064 mod 97-10 checksum, i.e. of a registry number:
+// the value (spaces ignored) is alphanumeric (uppercase) and its remainder,
+// with the letters standing for 10 (A) to 35 (Z), is 1. For IBANs, the first
+// 4 characters have to be moved to the end first.
+func mod97(v reflect.Value) (err error) {
+	s := String(v)
+	if n := strings.ReplaceAll(s, " ", ""); !mod97Rx.MatchString(n) || mod97Rem(n) != 1 {
+		return fmt.Errorf("%q has an invalid mod 97 checksum", s)
+	}
+
+	return
+}
+
+// mod11 makes a checker validating a mod 11 checksum, i.e. of a national
+// registry number, with the weights of its digits (the check digit included),
+// i.e. `mod11:weights=9 8 7 6 5 4 3 2 -1`: the weighted sum of the digits (spaces
+// and dashes ignored) must be divisible by 11. A final "X" stands for 10.
+func (v *Validator) mod11(args string) (c Checker, err error) {
+	kv, err := v.ParseKVArgs(args)
+	if err != nil {
+		return
+	}
+
+	var weights []int
+
+	for k, val := range kv {
+		if k != "weights" {
+			return nil, fmt.Errorf("%w %q: unknown key", ErrInvalidArg, k)
+		}
+
+		for w := range strings.FieldsSeq(val) {
+			n, err := ParseIntArg(w)
+			if err != nil {
+				return nil, err
+			}
+
+			weights = append(weights, n)
+		}
+	}
+
+	if len(weights) == 0 {
+		return nil, fmt.Errorf("%w %q: missing weights", ErrInvalidArg, args)
+	}
+
+	return func(val reflect.Value) (err error) {
+		s := String(val)
+		n := strings.NewReplacer(" ", "", "-", "").Replace(s)
+
+		if len(n) != len(weights) {
+			return fmt.Errorf("%q has %d digits, expecting %d", s, len(n), len(weights))
+		}
+
+		sum := 0
+
+		for i, r := range n {
+			switch {
+			case r >= '0' && r <= '9':
+				sum += int(r-'0') * weights[i]
+			case r == 'X' && i == len(n)-1:
+				sum += 10 * weights[i] //nolint:mnd // X stands for 10
+			default:
+				return fmt.Errorf("%q contains non-digit character: %q", s, r)
+			}
+		}
+
+		if sum%11 != 0 {
+			return fmt.Errorf("%q has an invalid mod 11 checksum", s)
+		}
+
+		return
+	}, nil
+}
+
+// mod97Rem returns the ISO 7064 mod 97-10 remainder of the alphanumeric
+// (uppercase) s, with the letters standing for 10 (A) to 35 (Z).
+func mod97Rem(s string) (rem int) {
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			rem = (rem*10 + int(r-'0')) % 97
+		case r >= 'A' && r <= 'Z':
+			rem = (rem*100 + int(r-'A') + 10) % 97
+		}
+	}
+
+	return
+}
+
+// noop is the checker of the modifiers (omitempty, omitnil),
+// which are handled by the validator itself.
+func noop(reflect.Value) error {
+	return nil
+}
+
+// message is the checker maker of the msg modifier, which
+// only needs to be parsed, it is handled by the validator itself.
+func message(string) (Checker, error) {
+	return noop, nil
+}
+
+// nilStruct is the checker maker of the nil_struct modifier, which
+// only needs to be parsed, it is handled by the validator itself.
+func nilStruct(args string) (Checker, error) {
+	if _, ok := nilStructPolicies[args]; !ok {
+		return nil, fmt.Errorf("invalid nil struct policy %q", args)
+	}
+
+	return noop, nil
+}
+
+func required(v reflect.Value) (err error) {
+	if isNil(v) {
+		return ErrAbsent
+	}
+
+	if isZero(v) {
+		return ErrEmpty
+	}
+
+	return
+}
+
+// And combines the checkers into one that passes if all of them do, failing
+// with the error of the first one that fails, i.e. to register a custom rule
+// built out of simpler ones: RegisterChecker("sku", And(alphaNum, skuPrefix)).
+func And(cx ...Checker) Checker {
+	return func(val reflect.Value) (err error) {
+		for _, ck := range cx {
+			if err = ck(val); err != nil {
+				return
+			}
+		}
+
+		return
+	}
+}
+
+// Or combines the checkers into one that passes if any of them does,
+// failing with the errors of all of them otherwise (the same as the
+// alternations do, i.e. `ipv4|ipv6`). It never passes without checkers.
+func Or(cx ...Checker) Checker {
+	return func(val reflect.Value) (err error) {
+		errs := make(altErrors, 0, len(cx))
+
+		for _, ck := range cx {
+			if err = ck(val); err == nil {
+				return
+			}
+
+			errs = append(errs, err)
+		}
+
+		if len(errs) == 0 {
+			return errors.New("no checkers to pass")
+		}
+
+		return errs
+	}
+}
+
+// Not inverts ck, making a checker that passes if ck fails (the same as the
+// negations do, i.e. `!alpha`). Errors wrapping [ErrInvalidArg] are not
+// check failures, so they are returned as they are.
+func Not(ck Checker) Checker {
+	return func(val reflect.Value) error {
+		if err := ck(val); err != nil {
+			if errors.Is(err, ErrInvalidArg) {
+				return err
+			}
+
+			return nil
+		}
+
+		return fmt.Errorf("%q must not pass the check", String(val))
+	}
+}
+
+// Regex allows you to easily create regex-based checkers.
+func Regex(arg string) (c Checker, err error) {
+	rx, err := compileRegex(arg)
+	if err != nil {
+		return
+	}
+
+	return func(v reflect.Value) (err error) {
+		act := String(v)
+		if rx.MatchString(act) {
+			return
+		}
+
+		return fmt.Errorf("%q does not match %s", act, arg)
+	}, nil
+}
+
+// Eq checks numbers for being == `arg` and things with a `len()`
+// (`array`, `chan`, `map`, `slice`, `string`) for having len == `arg`.
+// For [time.Time] values, `arg` is an RFC 3339 time or "now", optionally
+// followed by a signed duration (i.e. "now-24h"); same for Ne, Min and Max.
+// The [math/big] numbers and [Comparer]s are compared exactly, as well.
+func Eq(arg string) (c Checker, err error) {
+	return sizeCmp(arg, expEq)
+}
+
+// Ne checks numbers for being != `arg` and things with a `len()`
+// (`array`, `chan`, `map`, `slice`, `string`) for having len != `arg`.
+func Ne(arg string) (c Checker, err error) {
+	return sizeCmp(arg, expNotEq)
+}
+
+// Min checks numbers for being at least `arg` and things with a `len()`
+// (`array`, `chan`, `map`, `slice`, `string`) for having len at least `arg`.
+func Min(arg string) (c Checker, err error) {
+	return sizeCmp(arg, expMore)
+}
+
+// Max checks numbers for being at most `arg` and things with a `len()`
+// (`array`, `chan`, `map`, `slice`, `string`) for having len at most `arg`.
+func Max(arg string) (c Checker, err error) {
+	return sizeCmp(arg, expLess)
+}
+
+//nolint:nakedret,gocognit,funlen,cyclop // ok
+func sizeCmp(arg string, exp expOutcome) (c Checker, err error) {
+	if err = cmpArg(arg); err != nil {
+		return
+	}
+
+	label := expLabel[exp]
+
+	return func(v reflect.Value) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				if v, ok := r.(error); ok {
+					err = v
+				} else {
+					err = errors.New(fmt.Sprint(r))
+				}
+			}
+		}()
+
+		if ok, err := bigCmp(v, arg, exp); ok {
+			return err
+		}
+
+		switch {
+		case v.IsValid() && v.Type() == timeType:
+			return timeCmp(v, arg, exp)
+		case v.CanInt():
+			var x int64
+
+			if x, err = strconv.ParseInt(arg, 10, 64); err != nil {
+				return fmt.Errorf("%w %q: not an integer", ErrInvalidArg, arg)
+			}
+
+			if y := v.Int(); cmp2(y, x, exp) {
+				return fmt.Errorf("%d is %s %d", y, label, x)
+			}
+		case v.CanUint():
+			var x uint64
+
+			if x, err = strconv.ParseUint(arg, 10, 64); err != nil {
+				return fmt.Errorf("%w %q: not an unsigned integer", ErrInvalidArg, arg)
+			}
+
+			if y := v.Uint(); cmp2(y, x, exp) {
+				return fmt.Errorf("%d is %s %d", y, label, x)
+			}
+		case v.CanFloat():
+			var x float64
+
+			switch v.Kind() { //nolint:exhaustive // only floats can float
+			case reflect.Float32:
+				if x, err = strconv.ParseFloat(arg, 32); err != nil {
+					return fmt.Errorf("%w %q: not a number", ErrInvalidArg, arg)
+				}
+
+				vv := float32(v.Float())
+				if cmp2(vv, float32(x), exp) {
+					return fmt.Errorf("%.0f is %s %.0f", vv, label, x)
+				}
+			case reflect.Float64:
+				if x, err = strconv.ParseFloat(arg, 64); err != nil {
+					return fmt.Errorf("%w %q: not a number", ErrInvalidArg, arg)
+				}
+
+				vv := v.Float()
+				if cmp2(vv, x, exp) {
+					return fmt.Errorf("%.0f is %s %.0f", vv, label, x)
+				}
+			}
+		default:
+			var x int //nolint:varnamelen // ok
+
+			if x, err = strconv.Atoi(arg); err != nil {
+				return fmt.Errorf("%w %q: not an integer", ErrInvalidArg, arg)
+			}
+
+			for v.Kind() == reflect.Pointer {
+				if v.IsNil() {
+					return
+				}
+
+				v = v.Elem()
+			}
+
+			if v.Kind() == reflect.Invalid {
+				return nil
+			}
+
+			switch v.Kind() {
 			case reflect.Array, reflect.String:
 				if y := v.Len(); cmp2(y, x, exp) {
 					return fmt.Errorf("len %d is %s %d", y, label, x)
@@ -468,8 +1183,123 @@ func sizeCmp(arg string, exp expOutcome) (c Checker, err error) {
 	}, nil
 }
 
+// cmpArg validates the argument of the comparison checkers, which
+// is either a number (an integer, a decimal or a fraction) or a time.
+func cmpArg(arg string) (err error) {
+	if _, ok := new(big.Rat).SetString(arg); ok {
+		return
+	}
+
+	if _, err = parseTime(arg); err != nil {
+		return fmt.Errorf("%w %q: not a number or a time", ErrInvalidArg, arg)
+	}
+
+	return
+}
+
+func timeCmp(v reflect.Value, arg string, exp expOutcome) (err error) {
+	y, ok := Interface(v).(time.Time)
+	if !ok {
+		return errors.New("time check failed: cannot access unexported time")
+	}
+
+	x, err := parseTime(arg)
+	if err != nil {
+		return fmt.Errorf("%w %q: not a time", ErrInvalidArg, arg)
+	}
+
+	if cmpFailed(expOutcome(y.Compare(x)), exp) {
+		return fmt.Errorf("%s is %s %s", y.Format(time.RFC3339), expLabel[exp], x.Format(time.RFC3339))
+	}
+
+	return
+}
+
+// bigCmp compares the [math/big] numbers and [Comparer]s against arg,
+// ok is false for any other value.
+func bigCmp(v reflect.Value, arg string, exp expOutcome) (ok bool, err error) {
+	if !v.IsValid() || !v.CanInterface() || (v.Kind() == reflect.Pointer && v.IsNil()) {
+		return
+	}
+
+	ptr := v
+	if v.CanAddr() {
+		ptr = v.Addr()
+	} else if v.Kind() != reflect.Pointer {
+		ptr = reflect.New(v.Type())
+		ptr.Elem().Set(v)
+	}
+
+	var act int
+
+	switch x := ptr.Interface().(type) {
+	case *big.Int:
+		y, ok := new(big.Int).SetString(arg, 10)
+		if !ok {
+			return true, fmt.Errorf("%w %q: not an integer", ErrInvalidArg, arg)
+		}
+
+		act = x.Cmp(y)
+	case *big.Float: // Parsed at the precision of x, so that 0.1 == 0.1.
+		y, ok := new(big.Float).SetPrec(x.Prec()).SetString(arg)
+		if !ok {
+			return true, fmt.Errorf("%w %q: not a number", ErrInvalidArg, arg)
+		}
+
+		act = x.Cmp(y)
+	case *big.Rat:
+		y, ok := new(big.Rat).SetString(arg)
+		if !ok {
+			return true, fmt.Errorf("%w %q: not a number", ErrInvalidArg, arg)
+		}
+
+		act = x.Cmp(y)
+	case Comparer:
+		if act, err = x.CompareString(arg); err != nil {
+			return true, fmt.Errorf("%w %q: %w", ErrInvalidArg, arg, err)
+		}
+	default:
+		return
+	}
+
+	if cmpFailed(expOutcome(cmp.Compare(act, 0)), exp) {
+		err = fmt.Errorf("%s is %s %s", String(ptr.Elem()), expLabel[exp], arg)
+	}
+
+	return true, err
+}
+
+// parseTime parses a time argument: either an RFC 3339 time
+// or "now", optionally followed by a (signed) duration, i.e. "now-24h".
+func parseTime(arg string) (t time.Time, err error) {
+	rest, ok := strings.CutPrefix(arg, "now")
+	if !ok {
+		return time.Parse(time.RFC3339, arg)
+	}
+
+	t = time.Now()
+	if rest == "" {
+		return
+	}
+
+	if rest[0] != '+' && rest[0] != '-' {
+		return t, fmt.Errorf("invalid time %q", arg)
+	}
+
+	d, err := time.ParseDuration(rest)
+	if err != nil {
+		return
+	}
+
+	return t.Add(d), nil
+}
+
 func cmp2[T cmp.Ordered](a, b T, exp expOutcome) bool {
-	switch act := expOutcome(cmp.Compare(a, b)); exp {
+	return cmpFailed(expOutcome(cmp.Compare(a, b)), exp)
+}
+
+func cmpFailed(act, exp expOutcome) bool {
+	switch exp {
 	case expLess:
 		return act != expLess && act != 0
 	case expMore:
@@ -481,8 +1311,328 @@ func cmp2[T cmp.Ordered](a, b T, exp expOutcome) bool {
 	}
 }
 
-func oneOf(args string) (Checker, error) {
-	return Regex(fmt.Sprintf("^(%s)$", args))
+// numCmp makes checkers that parse (string encoded) numbers and compare
+// them numerically against arg, rather than by their length, i.e. `num_min:0.5`.
+// The comparison is exact (no float64 rounding involved).
+func numCmp(exp expOutcome) CheckerMaker {
+	return func(arg string) (c Checker, err error) {
+		x, ok := parseNum(arg)
+		if !ok {
+			return nil, fmt.Errorf("invalid number %q", arg)
+		}
+
+		return func(v reflect.Value) (err error) {
+			s := String(v)
+
+			y, ok := parseNum(s)
+			if !ok {
+				return fmt.Errorf("%q is not a number", s)
+			}
+
+			if cmpFailed(expOutcome(y.Cmp(x)), exp) {
+				return fmt.Errorf("%s is %s %s", s, expLabel[exp], arg)
+			}
+
+			return
+		}, nil
+	}
+}
+
+// parseNum parses a decimal number, with an optional sign and exponent.
+func parseNum(s string) (x *big.Rat, ok bool) {
+	if !numberRx.MatchString(s) {
+		return
+	}
+
+	return new(big.Rat).SetString(s)
+}
+
+// entropy checks that the value (i.e. an API key or a secret) has at least
+// `entropy:<bits>` bits of entropy, estimated as its length times the Shannon
+// entropy of its characters. The value itself is never part of the error.
+func entropy(arg string) (c Checker, err error) {
+	minBits, err := strconv.ParseFloat(arg, 64)
+	if err != nil || minBits <= 0 || math.IsInf(minBits, 0) {
+		return nil, fmt.Errorf("invalid number of bits %q", arg)
+	}
+
+	return func(v reflect.Value) (err error) {
+		if bits := entropyBits(String(v)); bits < minBits {
+			return fmt.Errorf("%.1f bits of entropy is less than %s", bits, arg)
+		}
+
+		return
+	}, nil
+}
+
+// entropyBits estimates the total (Shannon) entropy of s, in bits.
+func entropyBits(s string) (bits float64) {
+	freq, n := map[rune]float64{}, 0.0
+	for _, r := range s {
+		freq[r]++
+		n++
+	}
+
+	for _, f := range freq {
+		bits -= f * math.Log2(f/n)
+	}
+
+	return
+}
+
+// handles holds the allowed charset (anchored) and maximum length of the
+// user names (handles) of each platform supported by the `handle` checker.
+var handles = map[string]struct {
+	rx     *regexp.Regexp
+	maxLen int
+}{
+	"twitter":   {regexp.MustCompile(`^[A-Za-z0-9_]+$`), 15},
+	"x":         {regexp.MustCompile(`^[A-Za-z0-9_]+$`), 15},
+	"github":    {regexp.MustCompile(`^[A-Za-z0-9](?:-?[A-Za-z0-9])*$`), 39},
+	"instagram": {regexp.MustCompile(`^[A-Za-z0-9_](?:\.?[A-Za-z0-9_])*$`), 30},
+	"tiktok":    {regexp.MustCompile(`^[A-Za-z0-9_.]+[A-Za-z0-9_]$`), 24},
+	"reddit":    {regexp.MustCompile(`^[A-Za-z0-9_-]{3,}$`), 20},
+}
+
+// handle checks that the value is a valid user name (without the leading
+// "@") on the `handle:<platform>` platform, i.e. `handle:github`.
+func handle(arg string) (c Checker, err error) {
+	h, ok := handles[arg]
+	if !ok {
+		return nil, fmt.Errorf("unknown platform %q", arg)
+	}
+
+	return func(v reflect.Value) (err error) {
+		if s := String(v); utf8.RuneCountInString(s) > h.maxLen || !h.rx.MatchString(s) {
+			return fmt.Errorf("%q is not a valid %s handle", s, arg)
+		}
+
+		return
+	}, nil
+}
+
+// between checks that the value is within the (inclusive) `between:lo|hi`
+// range, with the same semantics as [Min] and [Max].
+func (v *Validator) between(args string) (c Checker, err error) {
+	list := v.ParseArgs(args)
+	if len(list) != 2 { //nolint:mnd // lo and hi
+		return nil, fmt.Errorf("need 2 arguments, got %d", len(list))
+	}
+
+	lo, err := Min(list[0])
+	if err != nil {
+		return
+	}
+
+	hi, err := Max(list[1])
+	if err != nil {
+		return
+	}
+
+	return func(val reflect.Value) (err error) {
+		if err = lo(val); err != nil {
+			return
+		}
+
+		return hi(val)
+	}, nil
+}
+
+// oneOf checks that the value is one of the given ones, i.e. `one_of:a|b`,
+// hinting at the nearest one, when it fails (see [FieldError.Hint]).
+func (v *Validator) oneOf(args string) (c Checker, err error) {
+	opts := v.ParseArgs(args)
+
+	rx, err := Regex(fmt.Sprintf("^(%s)$", strings.Join(opts, "|")))
+	if err != nil {
+		return
+	}
+
+	return func(val reflect.Value) (err error) {
+		if err = rx(val); err != nil {
+			err = withHint(err, String(val), opts)
+		}
+
+		return
+	}, nil
+}
+
+// notOneOf checks that the value is none of the given ones, i.e.
+// `not_one_of:admin|root`, the complement of one_of (i.e. for reserved names).
+// Unlike one_of, the values are compared literally, not as patterns.
+func (v *Validator) notOneOf(args string) (c Checker, err error) {
+	opts := v.ParseArgs(args)
+
+	return func(val reflect.Value) (err error) {
+		if act := String(val); slices.Contains(opts, act) {
+			return fmt.Errorf("%q must not be one of %v", act, opts)
+		}
+
+		return
+	}, nil
+}
+
+// subset checks that all the elements of a slice or array are one
+// of the given values, i.e. `subset:read|write|admin`.
+func (v *Validator) subset(args string) (c Checker, err error) {
+	set := v.ParseArgs(args)
+
+	return func(val reflect.Value) (err error) {
+		elems, err := elemStrings(val)
+		if err != nil {
+			return
+		}
+
+		for _, e := range elems {
+			if !slices.Contains(set, e) {
+				return withHint(fmt.Errorf("%q is not one of %v", e, set), e, set)
+			}
+		}
+
+		return
+	}, nil
+}
+
+// containsAll checks that a slice or array contains (at least)
+// all the given values, i.e. `contains_all:read|write`.
+func (v *Validator) containsAll(args string) (c Checker, err error) {
+	set := v.ParseArgs(args)
+
+	return func(val reflect.Value) (err error) {
+		elems, err := elemStrings(val)
+		if err != nil {
+			return
+		}
+
+		for _, e := range set {
+			if !slices.Contains(elems, e) {
+				return fmt.Errorf("%q is missing", e)
+			}
+		}
+
+		return
+	}, nil
+}
+
+// requiredKeys checks that a map holds all the given keys,
+// i.e. `required_keys:name|email`.
+func (v *Validator) requiredKeys(args string) (c Checker, err error) {
+	keys := v.ParseArgs(args)
+
+	return func(val reflect.Value) (err error) {
+		if val.Kind() != reflect.Map {
+			return fmt.Errorf("unsupported kind %s", val.Kind())
+		}
+
+		have := make(map[string]bool, val.Len())
+		for k := range val.Seq() {
+			have[String(k)] = true
+		}
+
+		for _, k := range keys {
+			if !have[k] {
+				return fmt.Errorf("key %q is missing", k)
+			}
+		}
+
+		return
+	}, nil
+}
+
+// noNilElements checks that a slice, array or map of pointers (or interfaces)
+// holds no nil elements, which would likely cause a panic further down the line.
+func noNilElements(v reflect.Value) (err error) {
+	switch v.Kind() { //nolint:exhaustive // only collections have elements
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			if isNilElem(v.Index(i)) {
+				return fmt.Errorf("element %d is nil", i)
+			}
+		}
+	case reflect.Map:
+		for k, e := range v.Seq2() {
+			if isNilElem(e) {
+				return fmt.Errorf("element %v is nil", k)
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported kind %s", v.Kind())
+	}
+
+	return
+}
+
+// isNilElem reports whether v is a nil pointer or interface, including
+// the interfaces holding nil pointers (i.e. a typed nil in an any).
+func isNilElem(v reflect.Value) bool {
+	for v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	return (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil()
+}
+
+// elemStrings returns the string representations of the elements of a slice or array.
+func elemStrings(v reflect.Value) (elems []string, err error) {
+	if k := v.Kind(); k != reflect.Slice && k != reflect.Array {
+		return nil, fmt.Errorf("unsupported kind %s", k)
+	}
+
+	elems = make([]string, v.Len())
+	for i := range v.Len() {
+		elems[i] = String(indirect(v.Index(i)))
+	}
+
+	return
+}
+
+// csvRecord validates that the value is a single CSV record with exactly
+// the given number of fields. An optional delimiter can be passed as
+// the second argument, i.e. `csv:5|;` (use `\t` for tabs).
+func (v *Validator) csvRecord(args string) (c Checker, err error) {
+	list := v.ParseArgs(args)
+	n, delim := list[0], strings.Join(list[1:], v.CheckArgListSep)
+
+	fields, err := ParseIntArg(n)
+	if err != nil {
+		return
+	}
+
+	if fields < 1 {
+		return nil, fmt.Errorf("%w: invalid number of fields %d", ErrInvalidArg, fields)
+	}
+
+	comma := ','
+
+	switch rx := []rune(strings.ReplaceAll(delim, `\t`, "\t")); len(rx) {
+	case 0:
+	case 1:
+		comma = rx[0]
+	default:
+		return nil, fmt.Errorf("invalid delimiter %q", delim)
+	}
+
+	if comma == '"' || comma == '\r' || comma == '\n' {
+		return nil, fmt.Errorf("invalid delimiter %q", delim)
+	}
+
+	return func(v reflect.Value) (err error) {
+		s := string(bytesOf(v))
+
+		r := csv.NewReader(strings.NewReader(s))
+		r.Comma = comma
+		r.FieldsPerRecord = fields
+
+		if _, err = r.Read(); err != nil {
+			return fmt.Errorf("%q is not a valid CSV record: %w", s, err)
+		}
+
+		if _, err = r.Read(); !errors.Is(err, io.EOF) {
+			return fmt.Errorf("%q is not a single CSV record", s)
+		}
+
+		return nil
+	}, nil
 }
 
 // TODO: When this is closed, remove this:
@@ -496,5 +1646,90 @@ func isZero(v reflect.Value) (ok bool) {
 		}
 	}()
 
+	switch x := Interface(v).(type) {
+	case time.Time:
+		return x.IsZero()
+	case netip.Addr:
+		return !x.IsValid()
+	case netip.Prefix:
+		return !x.IsValid()
+	case net.IP:
+		return len(x) == 0
+	case big.Int:
+		return x.Sign() == 0
+	case big.Float:
+		return x.Sign() == 0
+	case big.Rat:
+		return x.Sign() == 0
+	}
+
 	return v.IsZero()
 }
+
+// isNil reports whether v is nil (or was, before being indirected).
+func isNil(v reflect.Value) bool {
+	switch v.Kind() { //nolint:exhaustive // the rest cannot be nil
+	case reflect.Invalid:
+		return true
+	case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
+// bytesOf returns the raw bytes of []byte-like values (i.e. [json.RawMessage])
+// and the string representation of everything else.
+func bytesOf(v reflect.Value) []byte {
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+		return v.Bytes()
+	}
+
+	return []byte(String(v))
+}
+
+// withHint adds a hint to err, suggesting the option nearest to act (by edit
+// distance), if any is near enough, i.e. within a third of its length.
+func withHint(err error, act string, opts []string) error {
+	best, bestDist := "", 0
+
+	for _, opt := range opts {
+		if d := editDistance(act, opt); d > 0 && d <= utf8.RuneCountInString(opt)/3 &&
+			(best == "" || d < bestDist) {
+			best, bestDist = opt, d
+		}
+	}
+
+	if best == "" {
+		return err
+	}
+
+	return &hintError{err: err, hint: fmt.Sprintf("did you mean %q?", best)}
+}
+
+// editDistance returns the Levenshtein distance between a and b (in runes).
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev, cur := make([]int, len(rb)+1), make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := range ra {
+		cur[0] = i + 1
+
+		for j := range rb {
+			cost := 1
+			if ra[i] == rb[j] {
+				cost = 0
+			}
+
+			cur[j+1] = min(prev[j+1]+1, cur[j]+1, prev[j]+cost)
+		}
+
+		prev, cur = cur, prev
+	}
+
+	return prev[len(rb)]
+}
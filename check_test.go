@@ -1,8 +1,18 @@
 package vali
 
 import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/netip"
 	"reflect"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestEmail(t *testing.T) {
@@ -161,6 +171,88 @@ func TestIP(t *testing.T) {
 	}
 }
 
+func TestCIDR(t *testing.T) {
+	t.Parallel()
+
+	_, ipNet, err := net.ParseCIDR("10.0.0.0/8")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct { //nolint:govet // ok
+		name    string
+		input   any
+		wantErr bool
+	}{
+		{"Valid IPv4 CIDR", "192.168.1.0/24", false},
+		{"Valid IPv6 CIDR", "2001:db8::/32", false},
+		{"Valid prefix", netip.MustParsePrefix("10.0.0.0/8"), false},
+		{"Valid IPNet", *ipNet, false},
+		{"Invalid prefix", netip.PrefixFrom(netip.MustParseAddr("10.0.0.0"), 33), true},
+		{"Zero IPNet", net.IPNet{}, true},
+		{"Missing mask", "192.168.1.0", true},
+		{"Invalid mask", "192.168.1.0/33", true},
+		{"Not a CIDR", "not-a-cidr", true},
+		{"Numeric", 12345, true},
+		{"Empty string", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := cidr(val(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("cidr() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNetTypes(t *testing.T) {
+	t.Parallel()
+
+	type host struct {
+		Addr   netip.Addr   `validate:"required,ipv4"`
+		Addr6  *netip.Addr  `validate:"ipv6"`
+		IP     net.IP       `validate:"required,ip"`
+		Prefix netip.Prefix `validate:"required,cidr"`
+	}
+
+	v6 := netip.MustParseAddr("2001:db8::1")
+	valid := host{
+		Addr:   netip.MustParseAddr("10.0.0.1"),
+		Addr6:  &v6,
+		IP:     net.ParseIP("::ffff:10.0.0.1"),
+		Prefix: netip.MustParsePrefix("10.0.0.0/8"),
+	}
+
+	testCases := []struct {
+		mod func(*host)
+		exp string
+	}{
+		{func(*host) {}, ""},
+		{func(h *host) { h.Addr = netip.Addr{} }, "Addr: required check failed: value missing"},
+		{func(h *host) { h.Addr = v6 }, `Addr: ipv4 check failed: "2001:db8::1" is not a valid IPv4 address`},
+		{func(h *host) { h.Addr = netip.MustParseAddr("::ffff:10.0.0.1") }, ""},
+		{func(h *host) { h.Addr6 = &h.Addr }, `Addr6: ipv6 check failed: "10.0.0.1" is not a valid IPv6 address`},
+		{func(h *host) { h.Addr6 = nil }, ""},
+		{func(h *host) { h.IP = net.IP{} }, "IP: required check failed: value missing"},
+		{func(h *host) { h.IP = net.IP{1, 2, 3} }, `IP: ip check failed: "?010203" is not a valid IP address`},
+		{func(h *host) { h.Prefix = netip.Prefix{} }, "Prefix: required check failed: value missing"},
+		{func(h *host) { h.Prefix = netip.PrefixFrom(h.Addr, 99) }, "Prefix: required check failed: value missing"},
+	}
+
+	for _, tc := range testCases {
+		h := valid
+		tc.mod(&h)
+
+		if act := errString(Validate(h)); act != tc.exp {
+			t.Fatalf("Expected %q got %q", tc.exp, act)
+		}
+	}
+}
+
 func TestMAC(t *testing.T) {
 	t.Parallel()
 
@@ -229,6 +321,40 @@ func TestDomain(t *testing.T) {
 	}
 }
 
+func TestDNSLabel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct { //nolint:govet // ok
+		name    string
+		input   any
+		wantErr bool
+	}{
+		{"Valid label", "api", false},
+		{"Valid with hyphens", "my-app-2", false},
+		{"Valid single char", "a", false},
+		{"Valid leading digit", "1st", false},
+		{"Valid max length", strings.Repeat("a", 63), false},
+		{"Too long", strings.Repeat("a", 64), true},
+		{"Uppercase", "Api", true},
+		{"Leading hyphen", "-api", true},
+		{"Trailing hyphen", "api-", true},
+		{"Dot", "api.example", true},
+		{"Underscore", "my_app", true},
+		{"Empty string", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := dnsLabel(val(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("dnsLabel() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestISBN(t *testing.T) {
 	t.Parallel()
 
@@ -466,6 +592,131 @@ func TestJSON(t *testing.T) {
 	}
 }
 
+func TestGeoJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct { //nolint:govet // ok
+		name    string
+		input   any
+		wantErr bool
+	}{
+		{"Valid point", `{"type": "Point", "coordinates": [30, 10]}`, false},
+		{"Valid point with altitude", `{"type": "Point", "coordinates": [30, 10, 5]}`, false},
+		{"Valid multipoint", `{"type": "MultiPoint", "coordinates": [[10, 40], [40, 30]]}`, false},
+		{"Valid linestring", `{"type": "LineString", "coordinates": [[30, 10], [10, 30], [40, 40]]}`, false},
+		{"Valid multilinestring", `{"type": "MultiLineString", "coordinates": [[[10, 10], [20, 20]], [[40, 40], [30, 30]]]}`, false},
+		{"Valid polygon", `{"type": "Polygon", "coordinates": [[[30, 10], [40, 40], [20, 40], [30, 10]]]}`, false},
+		{"Valid multipolygon", `{"type": "MultiPolygon", "coordinates": [[[[30, 20], [45, 40], [10, 40], [30, 20]]]]}`, false},
+		{"Valid collection", `{"type": "GeometryCollection", "geometries": [{"type": "Point", "coordinates": [40, 10]}]}`, false},
+		{"Valid raw message", json.RawMessage(`{"type": "Point", "coordinates": [30, 10]}`), false},
+		{"Point with one coordinate", `{"type": "Point", "coordinates": [30]}`, true},
+		{"Point with nested coordinates", `{"type": "Point", "coordinates": [[30, 10]]}`, true},
+		{"Linestring with one position", `{"type": "LineString", "coordinates": [[30, 10]]}`, true},
+		{"Polygon not closed", `{"type": "Polygon", "coordinates": [[[30, 10], [40, 40], [20, 40], [10, 20]]]}`, true},
+		{"Polygon too few positions", `{"type": "Polygon", "coordinates": [[[30, 10], [40, 40], [30, 10]]]}`, true},
+		{"Missing coordinates", `{"type": "Point"}`, true},
+		{"Missing geometries", `{"type": "GeometryCollection"}`, true},
+		{"Invalid collection member", `{"type": "GeometryCollection", "geometries": [{"type": "Point"}]}`, true},
+		{"Unknown type", `{"type": "Circle", "coordinates": [30, 10]}`, true},
+		{"Feature is not a geometry", `{"type": "Feature", "geometry": null}`, true},
+		{"Not JSON", "not-json", true},
+		{"Numeric", 12345, true},
+		{"Empty string", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := geoJSON(val(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("geoJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestXML(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct { //nolint:govet // ok
+		name    string
+		input   any
+		wantErr bool
+	}{
+		{"Valid element", `<a/>`, false},
+		{"Valid with prolog", `<?xml version="1.0" encoding="UTF-8"?><urlset><url><loc>https://example.com</loc></url></urlset>`, false},
+		{"Valid with namespaces", `<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope"><soap:Body/></soap:Envelope>`, false},
+		{"Valid with whitespace and comments", "\n<!-- c -->\n<a>x</a>\n", false},
+		{"Valid raw bytes", []byte(`<a b="c"/>`), false},
+		{"Unclosed element", `<a><b></a>`, true},
+		{"Unterminated document", `<a>`, true},
+		{"Multiple roots", `<a/><b/>`, true},
+		{"Text outside root", `<a/>text`, true},
+		{"Unquoted attribute", `<a b=c/>`, true},
+		{"Not XML", "not-xml", true},
+		{"Numeric", 12345, true},
+		{"Empty string", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := xmL(val(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("xmL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCSVRecord(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct { //nolint:govet // ok
+		name       string
+		args       string
+		input      any
+		wantErr    bool
+		wantArgErr bool
+	}{
+		{"Valid record", "3", "a,b,c", false, false},
+		{"Valid quoted record", "3", `a,"b,c",d`, false, false},
+		{"Valid semicolon record", "3|;", "a;b;c", false, false},
+		{"Valid tab record", `2|\t`, "a\tb", false, false},
+		{"Valid raw bytes", "2", []byte("a,b"), false, false},
+		{"Too few fields", "3", "a,b", true, false},
+		{"Too many fields", "3", "a,b,c,d", true, false},
+		{"Wrong delimiter", "3|;", "a,b,c", true, false},
+		{"Multiple records", "2", "a,b\nc,d", true, false},
+		{"Bare quote", "2", `a,b"c`, true, false},
+		{"Invalid count", "x", "", false, true},
+		{"Zero count", "0", "", false, true},
+		{"Invalid delimiter", "3|;;", "", false, true},
+		{"Quote delimiter", `3|"`, "", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c, err := New().csvRecord(tt.args)
+			if (err != nil) != tt.wantArgErr {
+				t.Fatalf("csvRecord() error = %v, wantArgErr %v", err, tt.wantArgErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if err = c(val(tt.input)); (err != nil) != tt.wantErr {
+				t.Errorf("csvRecord() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestASCII(t *testing.T) {
 	t.Parallel()
 
@@ -554,6 +805,72 @@ func TestUppercase(t *testing.T) {
 	}
 }
 
+func TestNoHTML(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct { //nolint:govet // ok
+		name    string
+		input   any
+		wantErr bool
+	}{
+		{"Plain text", "Hello, World!", false},
+		{"Comparison", "1 < 2 and 3 > 2", false},
+		{"Ampersand", "Tom & Jerry", false},
+		{"Escaped tag", "&lt;b&gt;", false},
+		{"Tag", "<b>bold</b>", true},
+		{"Closing tag", "text</p>", true},
+		{"Script", `<script src="x.js">`, true},
+		{"Comment", "<!-- hidden -->", true},
+		{"Self closing", "<br/>", true},
+		{"Numeric", 12345, false},
+		{"Empty string", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := noHTML(val(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("noHTML() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHTMLEscaped(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct { //nolint:govet // ok
+		name    string
+		input   any
+		wantErr bool
+	}{
+		{"Plain text", "Hello, World!", false},
+		{"Named entities", "&lt;b&gt; Tom &amp; Jerry", false},
+		{"Numeric entities", "&#60; &#x3C; &#X3c;", false},
+		{"Less than", "1 < 2", true},
+		{"Greater than", "3 > 2", true},
+		{"Bare ampersand", "Tom & Jerry", true},
+		{"Unterminated entity", "&amp", true},
+		{"Invalid numeric entity", "&#xZZ;", true},
+		{"Tag", "<b>", true},
+		{"Numeric", 12345, false},
+		{"Empty string", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := htmlEscaped(val(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("htmlEscaped() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestValidateISBN10(t *testing.T) {
 	t.Parallel()
 
@@ -782,6 +1099,908 @@ func TestNPI(t *testing.T) {
 	}
 }
 
-func val[T any](s T) reflect.Value {
-	return reflect.ValueOf(s)
+func TestNINO(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct { //nolint:govet // ok
+		name    string
+		input   any
+		wantErr bool
+	}{
+		{"Valid NINO", "AB123456C", false},
+		{"Valid NINO with spaces", "JG 10 37 52 A", false},
+		{"Invalid first letter", "DA123456A", true},
+		{"Invalid second letter", "AO123456A", true},
+		{"Unallocated prefix", "GB123456A", true},
+		{"Administrative prefix", "TN123456A", true},
+		{"Invalid suffix", "AB123456E", true},
+		{"Missing suffix", "AB123456", true},
+		{"Lowercase", "ab123456c", true},
+		{"Empty string", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := nino(val(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("nino() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSIN(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct { //nolint:govet // ok
+		name    string
+		input   any
+		wantErr bool
+	}{
+		{"Valid SIN", "046454286", false},
+		{"Valid SIN with spaces", "046 454 286", false},
+		{"Valid SIN with dashes", "046-454-286", false},
+		{"Invalid checksum", "046454287", true},
+		{"Too short", "04645428", true},
+		{"Letters", "04645428a", true},
+		{"Empty string", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := sin(val(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("sin() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLEI(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct { //nolint:govet // ok
+		name    string
+		input   any
+		wantErr string
+	}{
+		{"Valid LEI", "5493001KJTIIGC8Y1R12", ""},
+		{"Valid LEI, all letters", "HWUPKR0MPOU8FGXBT394", ""},
+		{"Valid LEI, Stringer", foo("7LTWFZYICNSX8D621K86"), ""},
+		{"Invalid check digits", "5493001KJTIIGC8Y1R13", `"5493001KJTIIGC8Y1R13" has invalid LEI check digits`},
+		{"Swapped characters", "5493001KJTIIGC8Y1R21", `"5493001KJTIIGC8Y1R21" has invalid LEI check digits`},
+		{"Lowercase", "5493001kjtiigc8y1r12", `"5493001kjtiigc8y1r12" is not a valid LEI`},
+		{"Too short", "5493001KJTIIGC8Y1R1", `"5493001KJTIIGC8Y1R1" is not a valid LEI`},
+		{"Letters in check digits", "5493001KJTIIGC8Y1RAB", `"5493001KJTIIGC8Y1RAB" is not a valid LEI`},
+		{"Empty string", "", `"" is not a valid LEI`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := lei(val(tt.input)); errString(err) != tt.wantErr {
+				t.Errorf("lei() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDUNS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct { //nolint:govet // ok
+		name    string
+		input   any
+		wantErr bool
+	}{
+		{"Valid DUNS", "150483782", false},
+		{"Valid DUNS with dashes", "15-048-3782", false},
+		{"Valid DUNS, leading zero", "080000000", false},
+		{"Valid DUNS, Stringer", foo("150483782"), false},
+		{"Too short", "15048378", true},
+		{"Too long", "1504837821", true},
+		{"Letters", "15048378a", true},
+		{"Spaces", "15 048 3782", true},
+		{"Empty string", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := duns(val(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("duns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGitSHA(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct { //nolint:govet // ok
+		name    string
+		input   any
+		tag     string
+		wantErr string
+	}{
+		{"Abbreviated", "3c11e9a", "git_sha", ""},
+		{"Full", "3c11e9a0d1c7bbf5a4bd0e5cf1c5a2c2f3e0b7a1", "git_sha", ""},
+		{"Too short", "3c11e9", "git_sha", `git_sha check failed: "3c11e9" is not a valid git commit SHA`},
+		{"Uppercase", "3C11E9A", "git_sha", `git_sha check failed: "3C11E9A" is not a valid git commit SHA`},
+		{"SHA-256 is too long", strings.Repeat("a", 64), "git_sha", `git_sha check failed: "` + strings.Repeat("a", 64) +
+			`" is not a valid git commit SHA`},
+		{"Strict SHA-1", "3c11e9a0d1c7bbf5a4bd0e5cf1c5a2c2f3e0b7a1", "git_sha:strict", ""},
+		{"Strict SHA-256", strings.Repeat("0f", 32), "git_sha:strict", ""},
+		{"Strict abbreviated", "3c11e9a", "git_sha:strict", `git_sha check failed: "3c11e9a" is not a full git commit SHA`},
+		{"Strict not hex", strings.Repeat("g", 40), "git_sha:strict", `git_sha check failed: "` + strings.Repeat("g", 40) +
+			`" is not a full git commit SHA`},
+		{"Invalid arg", "3c11e9a", "git_sha:loose", `invalid checker git_sha:loose: unknown mode "loose"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if act := errString(Validate(tt.input, tt.tag)); act != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", act, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGitURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct { //nolint:govet // ok
+		name    string
+		input   any
+		wantErr bool
+	}{
+		{"scp-like", "git@github.com:alexaandru/vali.git", false},
+		{"scp-like without .git", "deploy@git.example.com:infra/manifests", false},
+		{"ssh", "ssh://git@github.com/alexaandru/vali.git", false},
+		{"ssh with port", "ssh://git@git.example.com:2222/infra/manifests.git", false},
+		{"https", "https://github.com/alexaandru/vali.git", false},
+		{"http", "http://github.com/alexaandru/vali.git", true},
+		{"https without path", "https://github.com/", true},
+		{"scp-like without user", "github.com:alexaandru/vali.git", true},
+		{"scp-like without path", "git@github.com:", true},
+		{"scp-like with absolute path", "git@git.example.com:/srv/git/vali.git", false},
+		{"scp-like with double slash", "git@github.com://vali.git", true},
+		{"Local path", "/srv/git/vali.git", true},
+		{"Empty string", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := gitURL(val(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("gitURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCVE(t *testing.T) {
+	t.Parallel()
+
+	next := fmt.Sprintf("CVE-%d-0001", time.Now().Year()+1)
+
+	tests := []struct { //nolint:govet // ok
+		name    string
+		input   any
+		wantErr string
+	}{
+		{"Valid CVE", "CVE-2024-3094", ""},
+		{"Valid long sequence", "CVE-2021-44228", ""},
+		{"Valid leading zeros", "CVE-1999-0001", ""},
+		{"Valid next year", next, ""},
+		{"Leading zeros in long sequence", "CVE-2021-04422", `"CVE-2021-04422" is not a valid CVE identifier`},
+		{"Short sequence", "CVE-2021-442", `"CVE-2021-442" is not a valid CVE identifier`},
+		{"Lowercase", "cve-2024-3094", `"cve-2024-3094" is not a valid CVE identifier`},
+		{"Too old", "CVE-1998-0001", `"CVE-1998-0001" has an invalid CVE year`},
+		{"Too new", "CVE-2999-0001", `"CVE-2999-0001" has an invalid CVE year`},
+		{"Empty string", "", `"" is not a valid CVE identifier`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := cve(val(tt.input)); errString(err) != tt.wantErr {
+				t.Errorf("cve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct { //nolint:govet // ok
+		name    string
+		input   any
+		wantErr string
+	}{
+		{"Valid OID", "1.3.6.1.4.1.311", ""},
+		{"Valid two arcs", "2.5", ""},
+		{"Valid zero arcs", "0.0", ""},
+		{"Valid second arc 39", "1.39.1", ""},
+		{"Valid large arc under 2", "2.999.3", ""},
+		{"Valid huge arc", "1.2.99999999999999999999999", ""},
+		{"Second arc over 39", "1.40", `"1.40" is not a valid OID (second arc over 39)`},
+		{"Huge second arc", "0.99999999999999999999", `"0.99999999999999999999" is not a valid OID (second arc over 39)`},
+		{"First arc over 2", "3.1", `"3.1" is not a valid OID`},
+		{"Single arc", "1", `"1" is not a valid OID`},
+		{"Leading zero", "1.03.6", `"1.03.6" is not a valid OID`},
+		{"Empty arc", "1..6", `"1..6" is not a valid OID`},
+		{"Trailing dot", "1.3.", `"1.3." is not a valid OID`},
+		{"Letters", "1.3.a", `"1.3.a" is not a valid OID`},
+		{"Empty string", "", `"" is not a valid OID`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := oid(val(tt.input)); errString(err) != tt.wantErr {
+				t.Errorf("oid() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBase58(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct { //nolint:govet // ok
+		name    string
+		input   any
+		wantErr bool
+	}{
+		{"Valid base58", "3mJr7AoUXx2Wqd", false},
+		{"Valid address", "1BoatSLRHtKNngkdXEeobR76b53LETtpyT", false},
+		{"Zero", "3mJr70", true},
+		{"Capital O", "3mJrO", true},
+		{"Capital I", "3mJrI", true},
+		{"Lowercase l", "3mJrl", true},
+		{"Base64 chars", "3mJr+/", true},
+		{"Empty string", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := base58(val(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("base58() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBech32(t *testing.T) {
+	t.Parallel()
+
+	// The test vectors of BIP 173 and BIP 350.
+	tests := []struct { //nolint:govet // ok
+		name    string
+		input   any
+		wantErr string
+	}{
+		{"Uppercase", "A12UEL5L", ""},
+		{"Lowercase", "a12uel5l", ""},
+		{"Long hrp", "an83characterlonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1tt5tgs", ""},
+		{"All chars", "abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw", ""},
+		{"Split", "split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w", ""},
+		{"Symbol hrp", "?1ezyfcl", ""},
+		{"Bech32m", "abcdef1l7aum6echk45nj3s0wdvt2fg8x9yrzpqzd3ryx", ""},
+		{"Segwit v0", "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq", ""},
+		{"Mixed case", "a12UEL5L", `"a12UEL5L" is not a valid bech32 string (mixed case)`},
+		{"Bad checksum", "a12uel5m", `"a12uel5m" has an invalid bech32 checksum`},
+		{"Empty hrp", "1nwldj5", `"1nwldj5" is not a valid bech32 string`},
+		{"Invalid data char", "x1b4n0q5v", `"x1b4n0q5v" is not a valid bech32 string`},
+		{"Short checksum", "li1dgmt3", `"li1dgmt3" is not a valid bech32 string`},
+		{"No separator", "pzry9x0s0muk", `"pzry9x0s0muk" is not a valid bech32 string`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := bech32(val(tt.input)); errString(err) != tt.wantErr {
+				t.Errorf("bech32() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUSState(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct { //nolint:govet // ok
+		name    string
+		input   any
+		tag     string
+		wantErr string
+	}{
+		{"State", "CA", "us_state", ""},
+		{"DC", "DC", "us_state", ""},
+		{"Lowercase", "ca", "us_state", `us_state check failed: "ca" is not a US state code`},
+		{"Territory", "PR", "us_state", `us_state check failed: "PR" is not a US state code`},
+		{"Military", "AE", "us_state", `us_state check failed: "AE" is not a US state code`},
+		{"Territory allowed", "PR", "us_state:territories", ""},
+		{"Military not allowed", "AE", "us_state:territories", `us_state check failed: "AE" is not a US state code`},
+		{"Military allowed", "AE", "us_state:military", ""},
+		{"State with options", "WY", "us_state:territories|military", ""},
+		{"Both allowed", "GU", "us_state:territories|military", ""},
+		{"Unknown", "XX", "us_state:territories|military", `us_state check failed: "XX" is not a US state code`},
+		{"Invalid option", "CA", "us_state:provinces", `invalid checker us_state:provinces: unknown option "provinces"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if act := errString(Validate(tt.input, tt.tag)); act != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", act, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCallingCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct { //nolint:govet // ok
+		name    string
+		input   any
+		wantErr bool
+	}{
+		{"With plus", "+40", false},
+		{"Without plus", "44", false},
+		{"North America", "1", false},
+		{"Three digits", "+353", false},
+		{"Non geographic", "+800", false},
+		{"Numeric", 49, false},
+		{"Unassigned", "+384", true},
+		{"Unassigned zone 2", "21", true},
+		{"Zero", "0", true},
+		{"Double plus", "++40", true},
+		{"International prefix", "0040", true},
+		{"Empty string", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := callingCode(val(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("callingCode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMod97Mod11(t *testing.T) {
+	t.Parallel()
+
+	bsn, isbn10 := "mod11:weights=9 8 7 6 5 4 3 2 -1", "mod11:weights=10 9 8 7 6 5 4 3 2 1"
+
+	tests := []struct { //nolint:govet // ok
+		name    string
+		input   any
+		tag     string
+		wantErr string
+	}{
+		{"Mod 97 LEI", "5493001KJTIIGC8Y1R12", "mod97", ""},
+		{"Mod 97 rearranged IBAN", "WEST 1234 5698 7654 32GB 82", "mod97", ""},
+		{"Mod 97 invalid", "WEST12345698765432GB83", "mod97", `mod97 check failed: "WEST12345698765432GB83" has an invalid mod 97 checksum`},
+		{"Mod 97 lowercase", "west12345698765432gb82", "mod97", `mod97 check failed: "west12345698765432gb82" has an invalid mod 97 checksum`},
+		{"Mod 11 BSN", "111222333", bsn, ""},
+		{"Mod 11 BSN invalid", "111222334", bsn, `mod11 check failed: "111222334" has an invalid mod 11 checksum`},
+		{"Mod 11 ISBN-10", "0-306-40615-2", isbn10, ""},
+		{"Mod 11 ISBN-10 with X", "080442957X", isbn10, ""},
+		{"Mod 11 X not last", "08044295X7", isbn10, `mod11 check failed: "08044295X7" contains non-digit character: 'X'`},
+		{"Mod 11 wrong length", "11122233", bsn, `mod11 check failed: "11122233" has 8 digits, expecting 9`},
+		{"Mod 11 numeric", 111222333, bsn, ""},
+		{"Mod 11 missing weights", "1", "mod11:weights=", `invalid checker mod11:weights=: invalid argument "weights=": missing weights`},
+		{"Mod 11 unknown key", "1", "mod11:w=1", `invalid checker mod11:w=1: invalid argument "w": unknown key`},
+		{"Mod 11 invalid weight", "1", "mod11:weights=a", `invalid checker mod11:weights=a: invalid argument "a": not an integer`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if act := errString(Validate(tt.input, tt.tag)); act != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", act, tt.wantErr)
+			}
+		})
+	}
+}
+
+func val[T any](s T) reflect.Value {
+	return reflect.ValueOf(s)
+}
+
+func TestTimeCmp(t *testing.T) {
+	t.Parallel()
+
+	past := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct { //nolint:govet // ok
+		name    string
+		input   any
+		tag     string
+		wantErr string
+	}{
+		{"Min RFC 3339", past, "min:2020-01-01T00:00:00Z", ""},
+		{"Min RFC 3339 fails", past, "min:2021-01-01T00:00:00Z", "min check failed: 2020-01-02T03:04:05Z is less than 2021-01-01T00:00:00Z"},
+		{"Max RFC 3339", past, "max:2021-01-01T00:00:00+02:00", ""},
+		{"Max RFC 3339 fails", past, "max:2020-01-01T00:00:00Z", "max check failed: 2020-01-02T03:04:05Z is more than 2020-01-01T00:00:00Z"},
+		{"Eq", past, "eq:2020-01-02T05:04:05+02:00", ""},
+		{"Ne fails", past, "ne:2020-01-02T03:04:05Z", "ne check failed: 2020-01-02T03:04:05Z is equal to 2020-01-02T03:04:05Z"},
+		{"Max now", past, "max:now", ""},
+		{"Min now", future, "min:now", ""},
+		{"Min now fails", past, "min:now-24h", "min check failed"},
+		{"Max now plus", future, "max:now+2h", ""},
+		{"Max now plus fails", future, "max:now+30m", "max check failed"},
+		{"Pointer", &past, "min:2020-01-01T00:00:00Z", ""},
+		{"Invalid time", past, "min:yesterday", `invalid checker min:yesterday: invalid argument "yesterday"`},
+		{"Invalid now", past, "min:nowish", `invalid checker min:nowish: invalid argument "nowish"`},
+		{"Invalid duration", past, "min:now-1y", `invalid checker min:now-1y: invalid argument "now-1y"`},
+		{"Required zero", time.Time{}, "required", "required check failed: value missing"},
+		{"Required zero with location", time.Time{}.In(time.FixedZone("X", 3600)), "required", "required check failed: value missing"},
+		{"Required", past, "required", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := Validate(tt.input, tt.tag)
+			if act := errString(err); !strings.HasPrefix(act, tt.wantErr) || (tt.wantErr == "") != (err == nil) {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	s := struct {
+		When  *time.Time `validate:"required,min:2020-01-01T00:00:00Z"`
+		since time.Time  `validate:"min:2020-01-01T00:00:00Z"`
+	}{When: &past, since: past}
+
+	if err := Validate(s); err == nil || err.Error() != "since: min check failed: time check failed: cannot access unexported time" {
+		t.Fatalf("Unexpected error %v", err)
+	}
+}
+
+type cents int64
+
+func (c cents) CompareString(arg string) (int, error) {
+	units, frac, _ := strings.Cut(arg, ".")
+
+	n, err := strconv.ParseInt(units+(frac + "00")[:2], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return cmp.Compare(int64(c), n), nil
+}
+
+func (c cents) String() string {
+	return fmt.Sprintf("%d.%02d", c/100, c%100)
+}
+
+func TestBigCmp(t *testing.T) {
+	t.Parallel()
+
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	tests := []struct { //nolint:govet // ok
+		name    string
+		input   any
+		tag     string
+		wantErr string
+	}{
+		{"Int min", huge, "min:123456789012345678901234567889", ""},
+		{"Int min fails", huge, "min:123456789012345678901234567891", "min check failed: 123456789012345678901234567890 is less than 123456789012345678901234567891"},
+		{"Int eq", *huge, "eq:123456789012345678901234567890", ""},
+		{"Int invalid arg", huge, "max:1.5", `max check failed: invalid argument "1.5": not an integer`},
+		{"Int required zero", big.NewInt(0), "required", "required check failed: value missing"},
+		{"Float max", big.NewFloat(0.1), "max:0.1", ""},
+		{"Float max fails", big.NewFloat(0.30000001), "max:0.3", "max check failed: 0.30000001 is more than 0.3"},
+		{"Float invalid arg", big.NewFloat(1), "max:x", `invalid checker max:x: invalid argument "x": not a number or a time`},
+		{"Rat ne fails", big.NewRat(1, 3), "ne:2/6", "ne check failed: 1/3 is equal to 2/6"},
+		{"Rat min", big.NewRat(1, 3), "min:0.33", ""},
+		{"Comparer min", cents(1050), "min:10.5", ""},
+		{"Comparer min fails", cents(1049), "min:10.5", "min check failed: 10.49 is less than 10.5"},
+		{"Comparer error", cents(1), "max:1/2", `max check failed: invalid argument "1/2": strconv.ParseInt: parsing "1/200"`},
+		{"Nil", (*big.Int)(nil), "max:1", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := Validate(tt.input, tt.tag)
+			if act := errString(err); !strings.HasPrefix(act, tt.wantErr) || (tt.wantErr == "") != (err == nil) {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	s := struct {
+		Price cents    `validate:"min:0.01"`
+		Total *big.Int `validate:"required,max:100"`
+	}{Price: 1, Total: big.NewInt(101)}
+
+	if act := errString(Validate(s)); act != "Total: max check failed: 101 is more than 100" {
+		t.Fatalf("Unexpected error %q", act)
+	}
+}
+
+func TestNumCmp(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct { //nolint:govet // ok
+		name    string
+		input   any
+		tag     string
+		wantErr string
+	}{
+		{"Min", "42.5", "num_min:40", ""},
+		{"Min fails", "39.99", "num_min:40", "num_min check failed: 39.99 is less than 40"},
+		{"Max", "1e3", "num_max:1000", ""},
+		{"Max fails", "1000.0001", "num_max:1000", "num_max check failed: 1000.0001 is more than 1000"},
+		{"Max exact", "0.3", "num_max:0.3", ""},
+		{"Eq", "-0.50", "num_eq:-.5", ""},
+		{"Ne fails", "+7", "num_ne:7", "num_ne check failed: +7 is equal to 7"},
+		{"Not a number", "abc", "num_min:1", `num_min check failed: "abc" is not a number`},
+		{"Fraction", "1/3", "num_min:0", `num_min check failed: "1/3" is not a number`},
+		{"Hex", "0x10", "num_min:0", `num_min check failed: "0x10" is not a number`},
+		{"Empty is skipped", "", "num_min:1", ""},
+		{"Pointer", p("5"), "num_min:1", ""},
+		{"Number", 5, "num_max:1", "num_max check failed: 5 is more than 1"},
+		{"Invalid arg", "5", "num_max:x", `invalid checker num_max:x: invalid number "x"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := Validate(tt.input, tt.tag)
+			if act := errString(err); !strings.HasPrefix(act, tt.wantErr) || (tt.wantErr == "") != (err == nil) {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEntropy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct { //nolint:govet // ok
+		name    string
+		input   any
+		tag     string
+		wantErr string
+	}{
+		{"Strong", "9f86d081884c7d659a2feaa0c55ad015", "entropy:64", ""},
+		{"Exact", "abcd", "entropy:8", ""},
+		{"Repeated", "aaaaaaaaaaaaaaaaaaaaaaaa", "entropy:64", "entropy check failed: 0.0 bits of entropy is less than 64"},
+		{"Short", "abcd", "entropy:8.5", "entropy check failed: 8.0 bits of entropy is less than 8.5"},
+		{"Unicode", "ăâîșț", "entropy:11", ""},
+		{"Empty is skipped", "", "entropy:64", ""},
+		{"Bytes", foo("password"), "entropy:64", "entropy check failed: 22.0 bits of entropy is less than 64"},
+		{"Invalid arg", "x", "entropy:x", `invalid checker entropy:x: invalid number of bits "x"`},
+		{"Negative arg", "x", "entropy:-1", `invalid checker entropy:-1: invalid number of bits "-1"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if act := errString(Validate(tt.input, tt.tag)); act != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", act, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHandle(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct { //nolint:govet // ok
+		name    string
+		input   any
+		tag     string
+		wantErr string
+	}{
+		{"Twitter", "jack_1", "handle:twitter", ""},
+		{"X", "jack", "handle:x", ""},
+		{"Twitter too long", "a23456789012345x", "handle:twitter", `handle check failed: "a23456789012345x" is not a valid twitter handle`},
+		{"Twitter with at", "@jack", "handle:twitter", `handle check failed: "@jack" is not a valid twitter handle`},
+		{"Twitter with dot", "ja.ck", "handle:twitter", `handle check failed: "ja.ck" is not a valid twitter handle`},
+		{"GitHub", "alexaandru", "handle:github", ""},
+		{"GitHub hyphen", "the-octo-cat", "handle:github", ""},
+		{"GitHub leading hyphen", "-octocat", "handle:github", `handle check failed: "-octocat" is not a valid github handle`},
+		{"GitHub double hyphen", "octo--cat", "handle:github", `handle check failed: "octo--cat" is not a valid github handle`},
+		{"GitHub underscore", "octo_cat", "handle:github", `handle check failed: "octo_cat" is not a valid github handle`},
+		{"Instagram", "the.cat_", "handle:instagram", ""},
+		{"Instagram trailing dot", "cat.", "handle:instagram", `handle check failed: "cat." is not a valid instagram handle`},
+		{"Instagram double dot", "the..cat", "handle:instagram", `handle check failed: "the..cat" is not a valid instagram handle`},
+		{"TikTok", "the.cat", "handle:tiktok", ""},
+		{"TikTok too short", "c", "handle:tiktok", `handle check failed: "c" is not a valid tiktok handle`},
+		{"Reddit", "spez-1", "handle:reddit", ""},
+		{"Reddit too short", "ab", "handle:reddit", `handle check failed: "ab" is not a valid reddit handle`},
+		{"Unicode", "jäck", "handle:twitter", `handle check failed: "jäck" is not a valid twitter handle`},
+		{"Empty is skipped", "", "handle:github", ""},
+		{"Stringer", foo("octocat"), "handle:github", ""},
+		{"Unknown platform", "x", "handle:myspace", `invalid checker handle:myspace: unknown platform "myspace"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if act := errString(Validate(tt.input, tt.tag)); act != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", act, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSubsetContainsAll(t *testing.T) {
+	t.Parallel()
+
+	type role string
+
+	tests := []struct { //nolint:govet // ok
+		name    string
+		input   any
+		tag     string
+		wantErr string
+	}{
+		{"Subset", []string{"read", "write"}, "subset:read|write|admin", ""},
+		{"Subset empty", []string{}, "subset:read|write", ""},
+		{"Subset fails", []string{"read", "root"}, "subset:read|write", `subset check failed: "root" is not one of [read write]`},
+		{"Subset array", [2]int{1, 3}, "subset:1|2|3", ""},
+		{"Subset pointers", []*role{p[role]("read")}, "subset: read | write ", ""},
+		{"Subset not a slice", "read", "subset:read", "subset check failed: unsupported kind string"},
+		{"Contains all", []role{"admin", "read", "write"}, "contains_all:read|write", ""},
+		{"Contains all fails", []string{"read"}, "contains_all:read|write", `contains_all check failed: "write" is missing`},
+		{"Contains all empty", []string{}, "contains_all:read", `contains_all check failed: "read" is missing`},
+		{"Contains all nil is skipped", []string(nil), "contains_all:read", ""},
+		{"Contains all not a slice", map[string]int{}, "contains_all:read", "contains_all check failed: unsupported kind map"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if act := errString(Validate(tt.input, tt.tag)); act != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", act, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNotOneOf(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct { //nolint:govet // ok
+		name    string
+		input   any
+		tag     string
+		wantErr string
+	}{
+		{"Allowed", "bob", "not_one_of:admin|root|system", ""},
+		{"Prefix is allowed", "administrator", "not_one_of:admin|root", ""},
+		{"Reserved", "root", "not_one_of:admin|root|system", `not_one_of check failed: "root" must not be one of [admin root system]`},
+		{"Case sensitive", "Root", "not_one_of:admin|root", ""},
+		{"Numbers", 1, "not_one_of:0|1", `not_one_of check failed: "1" must not be one of [0 1]`},
+		{"Zero is skipped", "", "not_one_of:a", ""},
+		{"Dot is literal", "axb", "not_one_of:a.b", ""},
+		{"Dot", "a.b", "not_one_of:a.b", `not_one_of check failed: "a.b" must not be one of [a.b]`},
+		{"Plus is literal", "cc", "not_one_of:c++|go", ""},
+		{"Plus", "c++", "not_one_of:c++|go", `not_one_of check failed: "c++" must not be one of [c++ go]`},
+		{"Star is literal", "aaa", "not_one_of:a*", ""},
+		{"Star", "a*", "not_one_of:a*", `not_one_of check failed: "a*" must not be one of [a*]`},
+		{"Parens are literal", "(", "not_one_of:(", `not_one_of check failed: "(" must not be one of [(]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if act := errString(Validate(tt.input, tt.tag)); act != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", act, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNoNilElements(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct { //nolint:govet // ok
+		name    string
+		input   any
+		wantErr string
+	}{
+		{"Pointers", []*int{p(1), p(2)}, ""},
+		{"Nil pointer", []*int{p(1), nil}, "no_nil_elements check failed: element 1 is nil"},
+		{"Interfaces", []any{1, "a"}, ""},
+		{"Nil interface", [2]any{1}, "no_nil_elements check failed: element 1 is nil"},
+		{"Typed nil interface", []any{1, (*int)(nil)}, "no_nil_elements check failed: element 1 is nil"},
+		{"Typed nil error", map[string]error{"a": (*FieldError)(nil)}, "no_nil_elements check failed: element a is nil"},
+		{"Map", map[string]*int{"a": p(1)}, ""},
+		{"Nil map value", map[string]*int{"a": nil}, "no_nil_elements check failed: element a is nil"},
+		{"Values", []int{0, 0}, ""},
+		{"Nil slice is skipped", []*int(nil), ""},
+		{"Not a collection", 1, "no_nil_elements check failed: unsupported kind int"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if act := errString(Validate(tt.input, "no_nil_elements")); act != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", act, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRequiredKeys(t *testing.T) {
+	t.Parallel()
+
+	type key string
+
+	tests := []struct { //nolint:govet // ok
+		name    string
+		input   any
+		tag     string
+		wantErr string
+	}{
+		{"All keys", map[string]any{"name": "bob", "email": "", "age": 3}, "required_keys:name|email", ""},
+		{"Missing key", map[string]string{"name": "bob"}, "required_keys:name|email", `required_keys check failed: key "email" is missing`},
+		{"Named keys", map[key]int{"a": 1}, "required_keys:a", ""},
+		{"Int keys", map[int]bool{1: true}, "required_keys:1|2", `required_keys check failed: key "2" is missing`},
+		{"Pointer", &map[string]int{"a": 1}, "required_keys:a", ""},
+		{"Empty map", map[string]int{}, "required_keys:a", `required_keys check failed: key "a" is missing`},
+		{"Nil map is skipped", map[string]int(nil), "required_keys:a", ""},
+		{"Not a map", []string{"a"}, "required_keys:a", "required_keys check failed: unsupported kind slice"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if act := errString(Validate(tt.input, tt.tag)); act != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", act, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct { //nolint:govet // ok
+		name  string
+		input any
+		tag   string
+		hint  string
+	}{
+		{"One of typo", "pendng", "one_of:pending|done", `did you mean "pending"?`},
+		{"One of case", "Done", "one_of:pending|done", `did you mean "done"?`},
+		{"One of nearest", "cancelle", "one_of:cancel|cancelled", `did you mean "cancelled"?`},
+		{"One of too far", "paid", "one_of:pending|done", ""},
+		{"One of too short", "b", "one_of:a|c", ""},
+		{"Subset typo", []string{"read", "wrte"}, "subset:read|write", `did you mean "write"?`},
+		{"Message keeps the hint", "pendng", "one_of:pending|done,msg:'bad status'", `did you mean "pending"?`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var fe *FieldError
+			if err := Validate(tt.input, tt.tag); !errors.As(err, &fe) {
+				t.Fatalf("Expected a *FieldError got %v", err)
+			}
+
+			if fe.Hint != tt.hint {
+				t.Errorf("Hint = %q, want %q", fe.Hint, tt.hint)
+			}
+
+			if tt.hint != "" && !strings.HasSuffix(fe.Error(), ", "+tt.hint) && fe.Err.Error() != "bad status" {
+				t.Errorf("Expected the hint in %q", fe.Error())
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		a, b string
+		exp  int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"pendng", "pending", 1},
+		{"héllo", "hello", 1},
+	} {
+		if act := editDistance(tc.a, tc.b); act != tc.exp {
+			t.Fatalf("Expected %d got %d for %q, %q", tc.exp, act, tc.a, tc.b)
+		}
+	}
+}
+
+func TestCombinators(t *testing.T) {
+	t.Parallel()
+
+	short, err := Max("3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	frac, err := Max("1.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct { //nolint:govet // ok
+		name    string
+		ck      Checker
+		val     string
+		wantErr string
+	}{
+		{"And pass", And(alpha, short), "abc", ""},
+		{"And fail first", And(alpha, short), "ab1", `"ab1" does not match (?i)^[a-z]*$`},
+		{"And fail second", And(alpha, short), "abcd", "len 4 is more than 3"},
+		{"And none", And(), "x", ""},
+		{"Or pass", Or(numeric, alpha), "abc", ""},
+		{"Or fail", Or(numeric, short), "abcd", `"abcd" does not match ^\d*$ or len 4 is more than 3`},
+		{"Or none", Or(), "x", "no checkers to pass"},
+		{"Not pass", Not(numeric), "abc", ""},
+		{"Not fail", Not(numeric), "123", `"123" must not pass the check`},
+		{"Not invalid arg", Not(frac), "abc", `invalid argument "1.5": not an integer`},
+		{"Nested", And(Not(numeric), Or(alpha, uuid)), "abc", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := tt.ck(reflect.ValueOf(tt.val)); errString(err) != tt.wantErr {
+				t.Errorf("Expected %q got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	v := New()
+	v.RegisterChecker("word", And(alpha, Not(Or(uppercase, short))))
+
+	if err := v.Validate("abcd", "word"); err != nil {
+		t.Fatal(err)
+	}
 }
@@ -0,0 +1,84 @@
+package vali
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"hash/adler32"
+	"hash/crc32"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// checksums are the algorithms known to the `checksum` checker.
+var checksums = map[string]func([]byte) uint32{
+	"crc32":   crc32.ChecksumIEEE,
+	"crc32c":  func(b []byte) uint32 { return crc32.Checksum(b, crc32.MakeTable(crc32.Castagnoli)) },
+	"adler32": adler32.Checksum,
+}
+
+// checksum makes a checker validating that the value equals the checksum
+// of the bytes of another field of the enclosing struct, i.e.
+// `checksum:crc32:Payload` (nested fields, i.e. Body.Raw, work too). The
+// algorithm is one of crc32 (IEEE), crc32c (Castagnoli) or adler32. The value
+// is either an integer or a (case insensitive) hex string of 8 digits.
+func (v *Validator) checksum(args string) (c Checker, err error) {
+	algo, field, _ := strings.Cut(args, v.CheckArgSep)
+
+	sum, ok := checksums[algo]
+	if !ok {
+		return nil, fmt.Errorf("unknown checksum algorithm %q", algo)
+	}
+
+	x, err := parser.ParseExpr(field)
+	if err != nil {
+		return nil, fmt.Errorf("invalid field %q", field)
+	}
+
+	switch x.(type) {
+	case *ast.Ident, *ast.SelectorExpr:
+	default:
+		return nil, fmt.Errorf("invalid field %q", field)
+	}
+
+	return func(val reflect.Value) (err error) {
+		f, ok := Interface(val).(Field)
+		if !ok {
+			return errors.New("no enclosing struct")
+		}
+
+		fv, err := exprFieldValue(x, f.Parent)
+		if err != nil {
+			return
+		}
+
+		exp := sum(bytesOf(fv))
+
+		var act uint64
+
+		switch self := indirect(f.Value); self.Kind() { //nolint:exhaustive // the rest are unsupported
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			act = uint64(self.Int()) //nolint:gosec // negative ones just fail
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			act = self.Uint()
+		case reflect.String:
+			if len(self.String()) != 8 { //nolint:mnd // 32 bits
+				return fmt.Errorf("%q is not a %s checksum", self.String(), algo)
+			}
+
+			if act, err = strconv.ParseUint(self.String(), 16, 32); err != nil {
+				return fmt.Errorf("%q is not a %s checksum", self.String(), algo)
+			}
+		default:
+			return fmt.Errorf("unsupported kind %s", self.Kind())
+		}
+
+		if act != uint64(exp) {
+			return fmt.Errorf("%s of %s is %08x, not %08x", algo, field, exp, act)
+		}
+
+		return
+	}, nil
+}
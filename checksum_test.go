@@ -0,0 +1,80 @@
+package vali
+
+import (
+	"testing"
+)
+
+func TestChecksum(t *testing.T) {
+	t.Parallel()
+
+	type (
+		body struct {
+			Raw []byte
+		}
+
+		manifest struct {
+			Payload string
+			CRC     uint32 `validate:"checksum:crc32:Payload"`
+			CRC32C  string `validate:"omitempty,checksum:crc32c:Payload"`
+			Adler   *int64 `validate:"omitempty,checksum:adler32:Body.Raw"`
+			Body    body
+		}
+	)
+
+	// The checksums of "hello": crc32 3610a686, crc32c 9a71bb4c, adler32 062c0215.
+	adler, badAdler := int64(0x062c0215), int64(1)
+
+	testCases := []struct {
+		val any
+		exp string
+	}{
+		{manifest{Payload: "hello", CRC: 0x3610a686}, ""},
+		{manifest{Payload: "hello", CRC: 1}, "CRC: checksum check failed: crc32 of Payload is 3610a686, not 00000001"},
+		{manifest{}, ""},
+		{manifest{Payload: "hello"}, "CRC: checksum check failed: crc32 of Payload is 3610a686, not 00000000"},
+		{manifest{Payload: "hello", CRC: 0x3610a686, CRC32C: "9A71BB4C"}, ""},
+		{manifest{Payload: "hello", CRC: 0x3610a686, CRC32C: "9a71bb4d"}, "CRC32C: checksum check failed: crc32c of Payload is 9a71bb4c, not 9a71bb4d"},
+		{manifest{Payload: "hello", CRC: 0x3610a686, CRC32C: "9a71bb"}, `CRC32C: checksum check failed: "9a71bb" is not a crc32c checksum`},
+		{manifest{Payload: "hello", CRC: 0x3610a686, CRC32C: "xx71bb4c"}, `CRC32C: checksum check failed: "xx71bb4c" is not a crc32c checksum`},
+		{manifest{Payload: "hello", CRC: 0x3610a686, Adler: &adler, Body: body{[]byte("hello")}}, ""},
+		{manifest{Payload: "hello", CRC: 0x3610a686, Adler: &badAdler, Body: body{[]byte("hello")}},
+			"Adler: checksum check failed: adler32 of Body.Raw is 062c0215, not 00000001"},
+		{p(manifest{Payload: "hello", CRC: 0x3610a686}), ""},
+	}
+
+	for _, tc := range testCases {
+		if act := errString(Validate(tc.val)); act != tc.exp {
+			t.Fatalf("Expected %q got %q for %v", tc.exp, act, tc.val)
+		}
+	}
+
+	type (
+		unknownField struct {
+			CRC uint32 `validate:"checksum:crc32:Missing"`
+		}
+
+		unsupported struct {
+			Payload string
+			CRC     float64 `validate:"checksum:crc32:Payload"`
+		}
+	)
+
+	errCases := []struct {
+		val any
+		tag string
+		exp string
+	}{
+		{unknownField{CRC: 1}, "", "CRC: checksum check failed: unknown field Missing"},
+		{unsupported{CRC: 1}, "", "CRC: checksum check failed: unsupported kind float64"},
+		{uint32(1), "checksum:crc32:Payload", "checksum check failed: cannot access Payload of uint32"},
+		{uint32(1), "checksum:md5:Payload", `invalid checker checksum:md5:Payload: unknown checksum algorithm "md5"`},
+		{uint32(1), "checksum:crc32:1+", `invalid checker checksum:crc32:1+: invalid field "1+"`},
+		{uint32(1), "checksum:crc32", `invalid checker checksum:crc32: invalid field ""`},
+	}
+
+	for _, tc := range errCases {
+		if act := errString(Validate(tc.val, tc.tag)); act != tc.exp {
+			t.Fatalf("Expected %q got %q for %v", tc.exp, act, tc.val)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/alexaandru/vali/cmd/vali
+
+go 1.25.6
+
+require (
+	github.com/alexaandru/vali v0.0.0
+	go.yaml.in/yaml/v3 v3.0.5
+)
+
+replace github.com/alexaandru/vali => ../../
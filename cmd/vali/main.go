@@ -0,0 +1,220 @@
+// Command vali validates JSON or YAML documents against the vali tags
+// of a Go struct type, using the exact same rules as the code using it:
+//
+//	vali [-tag validate[,binding]] <import/path.Type> <file.json|file.yaml>...
+//
+// The type is given by its fully qualified name (i.e. example.com/app/config.Config)
+// or by a relative package path (i.e. ./internal/config.Config) and it is resolved
+// in the context of the Go module in the current directory, which must depend on
+// vali. For each file the first failure (with its field path) is printed and
+// the exit status is 1 if any of them is invalid.
+//
+// Under the hood, it generates (and runs) a tiny program which decodes each
+// document into the type and validates it with [vali.Validator.ValidateJSON].
+// YAML documents are converted to JSON first, so the `json` tags apply to them too.
+//
+// It lives in its own module, so that vali itself stays dependency free.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/alexaandru/vali"
+	"go.yaml.in/yaml/v3"
+)
+
+var mainTmpl = template.Must(template.New("main").Parse(`package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/alexaandru/vali"
+	target {{printf "%q" .Pkg}}
+)
+
+func main() {
+	v, failed := vali.New({{range $i, $tag := .Tags}}{{if $i}}, {{end}}{{printf "%q" $tag}}{{end}}), false
+
+	// The arguments are pairs of displayed names and the actual file names.
+	for i := 1; i+1 < len(os.Args); i += 2 {
+		fn := os.Args[i]
+
+		data, err := os.ReadFile(os.Args[i+1])
+		if err == nil {
+			var dst target.{{.Type}}
+
+			err = v.ValidateJSON(data, &dst)
+		}
+
+		if err != nil {
+			fmt.Printf("%s: %v\n", fn, err)
+
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+`))
+
+var errUsage = errors.New("usage: vali [-tag name] <import/path.Type> <file>...")
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("vali", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	tag := fs.String("tag", vali.DefaultValidatorTagName,
+		"the struct tag name, or a comma separated list of them, in the fallback order")
+
+	if err := fs.Parse(args); err != nil {
+		return 2 //nolint:mnd // usage error
+	}
+
+	if fs.NArg() < 2 { //nolint:mnd // type and at least one file
+		fmt.Fprintln(stderr, errUsage)
+		return 2 //nolint:mnd // usage error
+	}
+
+	if err := validate(*tag, fs.Arg(0), fs.Args()[1:], stdout, stderr); err != nil {
+		var exit *exec.ExitError
+		if errors.As(err, &exit) {
+			return exit.ExitCode()
+		}
+
+		fmt.Fprintln(stderr, err)
+
+		return 1
+	}
+
+	return 0
+}
+
+func validate(tag, typ string, files []string, stdout, stderr io.Writer) (err error) {
+	pkg, name, err := splitType(typ)
+	if err != nil {
+		return
+	}
+
+	if pkg, err = importPath(pkg); err != nil {
+		return
+	}
+
+	// The program must be generated inside the current module, for the imports to resolve.
+	dir, err := os.MkdirTemp(".", ".vali-")
+	if err != nil {
+		return
+	}
+
+	defer os.RemoveAll(dir)
+
+	var src bytes.Buffer
+
+	tags := strings.Split(tag, ",")
+	for i := range tags {
+		tags[i] = strings.TrimSpace(tags[i])
+	}
+
+	if err = mainTmpl.Execute(&src, map[string]any{"Pkg": pkg, "Tags": tags, "Type": name}); err != nil {
+		return
+	}
+
+	if err = os.WriteFile(filepath.Join(dir, "main.go"), src.Bytes(), 0o600); err != nil {
+		return
+	}
+
+	docs := make([]string, 0, 2*len(files)) //nolint:mnd // pairs
+
+	for _, fn := range files {
+		var doc string
+
+		if doc, err = jsonDoc(dir, fn); err != nil {
+			return
+		}
+
+		docs = append(docs, fn, doc)
+	}
+
+	cmd := exec.Command("go", append([]string{"run", "./" + filepath.Base(dir)}, docs...)...) //nolint:gosec // ok
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+
+	return cmd.Run()
+}
+
+// splitType splits a fully qualified type name into its package and name.
+func splitType(typ string) (pkg, name string, err error) {
+	i := strings.LastIndex(typ, ".")
+	if i <= 0 || i == len(typ)-1 || strings.Contains(typ[i:], "/") {
+		return "", "", fmt.Errorf("invalid type %q, expected <import/path.Type>", typ)
+	}
+
+	return typ[:i], typ[i+1:], nil
+}
+
+// importPath resolves relative package paths (i.e. ./config) to import paths.
+func importPath(pkg string) (string, error) {
+	if !strings.HasPrefix(pkg, "./") && !strings.HasPrefix(pkg, "../") && pkg != "." {
+		return pkg, nil
+	}
+
+	out, err := exec.Command("go", "list", "-f", "{{.ImportPath}}", pkg).Output() //nolint:gosec // ok
+	if exit := (*exec.ExitError)(nil); errors.As(err, &exit) {
+		// Not wrapped, only the exit code of the generated program is passed through.
+		return "", fmt.Errorf("cannot resolve package %s: %s", pkg, bytes.TrimSpace(exit.Stderr))
+	} else if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// jsonDoc returns the (absolute) name of the JSON document to validate:
+// fn itself or, for YAML documents, the name of its JSON conversion, saved in dir.
+func jsonDoc(dir, fn string) (doc string, err error) {
+	if ext := strings.ToLower(filepath.Ext(fn)); ext != ".yaml" && ext != ".yml" {
+		return filepath.Abs(fn)
+	}
+
+	data, err := os.ReadFile(fn)
+	if err != nil {
+		return
+	}
+
+	var x any
+
+	if err = yaml.Unmarshal(data, &x); err != nil {
+		return "", fmt.Errorf("%s: %w", fn, err)
+	}
+
+	if data, err = json.Marshal(x); err != nil {
+		return "", fmt.Errorf("%s: %w", fn, err)
+	}
+
+	f, err := os.CreateTemp(dir, "*.json")
+	if err != nil {
+		return
+	}
+
+	defer f.Close()
+
+	if _, err = f.Write(data); err != nil {
+		return
+	}
+
+	return filepath.Abs(f.Name())
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	root, err := filepath.Abs("../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/app\n\ngo 1.25.6\n\nrequire github.com/alexaandru/vali v0.0.0\n\n" +
+			"replace github.com/alexaandru/vali => " + root + "\n",
+		"config/config.go": "package config\n\ntype Config struct {\n" +
+			"\tName string `json:\"name\" validate:\"required\"`\n" +
+			"\tPort int    `json:\"port\" validate:\"min:1,max:65535\"`\n}\n",
+		"ok.json":  `{"name": "app", "port": 8080}`,
+		"bad.json": `{"name": "app", "port": 0}`,
+		"ok.yaml":  "name: app\nport: 80\n",
+		"bad.yml":  "port: 80\n",
+	}
+
+	for fn, body := range files {
+		fn = filepath.Join(dir, fn)
+		if err = os.MkdirAll(filepath.Dir(fn), 0o700); err != nil {
+			t.Fatal(err)
+		}
+
+		if err = os.WriteFile(fn, []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Chdir(dir)
+
+	testCases := []struct {
+		args   []string
+		exit   int
+		stdout string
+		stderr string
+	}{
+		{[]string{"example.com/app/config.Config", "ok.json", "ok.yaml"}, 0, "", ""},
+		{[]string{"./config.Config", "ok.json", "bad.json", "bad.yml"}, 1,
+			"bad.json: Port: min check failed: 0 is less than 1\nbad.yml: Name: required check failed: value missing\n", ""},
+		{[]string{"-tag", "other", "./config.Config", "bad.json"}, 0, "", ""},
+		{[]string{"-tag", "other, validate", "./config.Config", "bad.json"}, 1,
+			"bad.json: Port: min check failed: 0 is less than 1\n", ""},
+		{[]string{"./config.Config"}, 2, "", "usage: vali"},
+		{[]string{"-bogus"}, 2, "", "flag provided but not defined"},
+		{[]string{"Config", "ok.json"}, 1, "", `invalid type "Config"`},
+		{[]string{"./nope.Config", "ok.json"}, 1, "", "cannot resolve package ./nope"},
+		{[]string{"./config.Config", "missing.yaml"}, 1, "", "no such file or directory"},
+	}
+
+	for _, tc := range testCases {
+		var stdout, stderr bytes.Buffer
+
+		if exit := run(tc.args, &stdout, &stderr); exit != tc.exit {
+			t.Fatalf("Expected exit %d got %d for %v: %s", tc.exit, exit, tc.args, stderr.String())
+		}
+
+		if act := stdout.String(); act != tc.stdout {
+			t.Fatalf("Expected stdout %q got %q for %v", tc.stdout, act, tc.args)
+		}
+
+		if act := stderr.String(); !strings.Contains(act, tc.stderr) {
+			t.Fatalf("Expected stderr %q got %q for %v", tc.stderr, act, tc.args)
+		}
+	}
+
+	if left, _ := filepath.Glob(".vali-*"); len(left) > 0 {
+		t.Fatalf("Expected the generated program to be removed, found %v", left)
+	}
+}
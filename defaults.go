@@ -0,0 +1,124 @@
+package vali
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultValue is the checker maker of the default modifier, which only
+// needs to be parsed, it is applied by the validator itself (see applyDefault).
+func defaultValue(string) (Checker, error) {
+	return noop, nil
+}
+
+// applyDefault sets the (settable) field fv to the value of the `default:<value>`
+// modifier in tag, if any, when fv is zero. Nil pointers are allocated, so that
+// i.e. `Port *int` gets a pointer to the default.
+func (v *Validator) applyDefault(fv reflect.Value, tag string) (err error) {
+	if !strings.Contains(tag, "default"+v.CheckArgSep) {
+		return
+	}
+
+	_, chkNames, err := v.parse(tag)
+	if err != nil {
+		return nil // Reported by the validation of tag itself.
+	}
+
+	for _, name := range chkNames {
+		if name, args, _ := strings.Cut(name, v.CheckArgSep); name == "default" {
+			return setDefault(fv, unquote(args))
+		}
+	}
+
+	return
+}
+
+// setDefault sets fv (through any pointers) to arg, if zero and settable.
+func setDefault(fv reflect.Value, arg string) (err error) {
+	if !fv.CanSet() || !isZero(indirect(fv)) {
+		return
+	}
+
+	x := reflect.New(indirectType(fv.Type())).Elem()
+	if err = parseDefault(x, arg); err != nil {
+		return fmt.Errorf("%w default:%s: %w", ErrInvalidChecker, arg, err)
+	}
+
+	for fv.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+
+		fv = fv.Elem()
+	}
+
+	fv.Set(x)
+
+	return
+}
+
+// parseDefault parses arg into x, as per its type: times (see [Min]),
+// durations, [encoding.TextUnmarshaler]s, strings, booleans and numbers.
+func parseDefault(x reflect.Value, arg string) (err error) {
+	switch x.Interface().(type) {
+	case time.Time:
+		t, err := parseTime(arg)
+		if err == nil {
+			x.Set(reflect.ValueOf(t))
+		}
+
+		return err
+	case time.Duration:
+		d, err := time.ParseDuration(arg)
+		if err == nil {
+			x.SetInt(int64(d))
+		}
+
+		return err
+	}
+
+	if tu, ok := x.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return tu.UnmarshalText([]byte(arg))
+	}
+
+	switch x.Kind() { //nolint:exhaustive // the rest have no text form
+	case reflect.String:
+		x.SetString(arg)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(arg)
+		if err == nil {
+			x.SetBool(b)
+		}
+
+		return err
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(arg, 10, x.Type().Bits())
+		if err == nil {
+			x.SetInt(n)
+		}
+
+		return err
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(arg, 10, x.Type().Bits())
+		if err == nil {
+			x.SetUint(n)
+		}
+
+		return err
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(arg, x.Type().Bits())
+		if err == nil {
+			x.SetFloat(f)
+		}
+
+		return err
+	default:
+		return fmt.Errorf("no default for %s", x.Type())
+	}
+
+	return
+}
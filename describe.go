@@ -0,0 +1,124 @@
+package vali
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+type (
+	// Description describes the validation rules of a struct type, one
+	// entry per validated field. It renders to JSON via [encoding/json]
+	// and to Markdown via [Description.Markdown].
+	Description []FieldRules
+
+	// FieldRules describes the validation rules of a single field.
+	FieldRules struct {
+		Field   string      `json:"field"`
+		Type    string      `json:"type"`
+		Checks  []CheckRule `json:"checks"`
+		Message string      `json:"message,omitempty"`
+	}
+
+	// CheckRule describes a single check, i.e. `one_of:a|b` has the name
+	// "one_of" and the args "a|b". Alternations (`ipv4|ipv6`) and negations
+	// (`!alpha`) are described as written. The description is the one the
+	// checker was registered with (see [Validator.DescribeChecker]), if any,
+	// and the groups are the ones the check is restricted to (see
+	// [Validator.GroupSep]), if any.
+	CheckRule struct {
+		Name        string   `json:"name"`
+		Args        string   `json:"args,omitempty"`
+		Description string   `json:"description,omitempty"`
+		Groups      []string `json:"groups,omitempty"`
+	}
+)
+
+// Describe describes the rules of t using the [DefaultValidator].
+// See [Validator.Describe] for details.
+func Describe(t reflect.Type) (Description, error) {
+	return DefaultValidator.Describe(t)
+}
+
+// Describe walks the struct type t, the same way as [Validator.CheckStruct]
+// does, and describes the validation rules (struct tags and external rules)
+// of each field, i.e. for embedding them into API docs. It fails if any of
+// the tags is invalid.
+func (v *Validator) Describe(t reflect.Type) (d Description, err error) {
+	err = v.walkTags(t, func(path string, f reflect.StructField, tag string) (err error) {
+		if err = v.CheckTag(tag); err != nil {
+			return
+		}
+
+		fr := FieldRules{Field: path, Type: f.Type.String()}
+
+		checks, err := v.splitChecks(tag)
+		if err != nil {
+			return
+		}
+
+		for _, check := range checks {
+			check, groups := v.cutGroups(check)
+			if check == "" {
+				continue
+			}
+
+			slices.Reverse(groups) // As written.
+
+			if alts := v.alternatives(check); len(alts) > 1 {
+				fr.Checks = append(fr.Checks, CheckRule{Name: check, Groups: groups})
+				continue
+			}
+
+			name, args, _ := strings.Cut(check, v.CheckArgSep)
+			if args = unquote(args); name == "msg" {
+				fr.Message = args
+				continue
+			}
+
+			info, _ := v.DescribeChecker(name)
+			fr.Checks = append(fr.Checks, CheckRule{Name: name, Args: args, Description: info.Description, Groups: groups})
+		}
+
+		d = append(d, fr)
+
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// Markdown renders d as a Markdown table.
+func (d Description) Markdown() string {
+	var sb strings.Builder
+
+	sb.WriteString("| Field | Type | Checks | Message |\n| --- | --- | --- | --- |\n")
+
+	for _, fr := range d {
+		checks := make([]string, len(fr.Checks))
+		for i, c := range fr.Checks {
+			checks[i] = "`" + c.Name + "`"
+			if c.Args != "" {
+				checks[i] = fmt.Sprintf("`%s:%s`", c.Name, c.Args)
+			}
+
+			if len(c.Groups) > 0 {
+				checks[i] += " (" + strings.Join(c.Groups, ", ") + ")"
+			}
+		}
+
+		fmt.Fprintf(&sb, "| %s | `%s` | %s | %s |\n", mdEscape(fr.Field), mdEscape(fr.Type),
+			mdEscape(strings.Join(checks, ", ")), mdEscape(fr.Message))
+	}
+
+	return sb.String()
+}
+
+// mdEscape escapes the pipes and newlines, which would break the table cells.
+func mdEscape(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
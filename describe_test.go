@@ -0,0 +1,123 @@
+package vali
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type (
+	describedAddress struct {
+		City string `validate:"required"`
+	}
+
+	describedUser struct {
+		Name      string `validate:"required,msg:'name, please'"`
+		Role      string `validate:"one_of:admin|user"`
+		IP        string `validate:"ipv4|ipv6"`
+		Nick      *string
+		Billing   describedAddress
+		Shipping  *describedAddress `validate:"!alpha"`
+		Addresses []describedAddress
+	}
+)
+
+func TestDescribe(t *testing.T) {
+	t.Parallel()
+
+	d, err := Describe(reflect.TypeFor[describedUser]())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := `[{"field":"Name","type":"string","checks":[{"name":"required","description":"must NOT be IsZero()"}],"message":"name, please"},` +
+		`{"field":"Role","type":"string","checks":[{"name":"one_of","args":"admin|user","description":"must be one of the args"}]},` +
+		`{"field":"IP","type":"string","checks":[{"name":"ipv4|ipv6"}]},` +
+		`{"field":"Billing.City","type":"string","checks":[{"name":"required","description":"must NOT be IsZero()"}]},` +
+		`{"field":"Shipping","type":"*vali.describedAddress","checks":[{"name":"!alpha"}]},` +
+		`{"field":"Shipping.City","type":"string","checks":[{"name":"required","description":"must NOT be IsZero()"}]},` +
+		`{"field":"Addresses[].City","type":"string","checks":[{"name":"required","description":"must NOT be IsZero()"}]}]`
+
+	if act := string(data); act != exp {
+		t.Fatalf("Expected %s got %s", exp, act)
+	}
+
+	exp = "| Field | Type | Checks | Message |\n| --- | --- | --- | --- |\n" +
+		"| Name | `string` | `required` | name, please |\n" +
+		"| Role | `string` | `one_of:admin\\|user` |  |\n" +
+		"| IP | `string` | `ipv4\\|ipv6` |  |\n" +
+		"| Billing.City | `string` | `required` |  |\n" +
+		"| Shipping | `*vali.describedAddress` | `!alpha` |  |\n" +
+		"| Shipping.City | `string` | `required` |  |\n" +
+		"| Addresses[].City | `string` | `required` |  |\n"
+
+	if act := d.Markdown(); act != exp {
+		t.Fatalf("Expected:\n%s\ngot:\n%s", exp, act)
+	}
+
+	_, err = Describe(reflect.TypeFor[struct {
+		A string `validate:"bogus"`
+	}]())
+	if !errors.Is(err, ErrInvalidChecker) {
+		t.Fatalf("Expected %v got %v", ErrInvalidChecker, err)
+	}
+}
+
+func TestDescribeChecker(t *testing.T) {
+	t.Parallel()
+
+	v := New()
+	v.RegisterCheckerMaker("divisible_by", func(string) (Checker, error) { return noop, nil },
+		CheckerInfo{Description: "must be divisible by n", Kinds: []string{"numeric"}, Args: "<n>", Example: "divisible_by:3"})
+	v.RegisterChecker("even", noop)
+
+	if info, ok := v.DescribeChecker("between"); !ok || info.Args != "<a>|<b>" {
+		t.Fatalf("Expected the between info got %#v, %v", info, ok)
+	}
+
+	if info, ok := v.DescribeChecker("even"); !ok || !reflect.DeepEqual(info, CheckerInfo{}) {
+		t.Fatalf("Expected an empty info got %#v, %v", info, ok)
+	}
+
+	if _, ok := v.DescribeChecker("odd"); ok {
+		t.Fatal("Expected no info for an unregistered checker")
+	}
+
+	c := v.Child()
+	c.RegisterChecker("email", noop)
+
+	if info, _ := c.DescribeChecker("divisible_by"); info.Example != "divisible_by:3" {
+		t.Fatalf("Expected the inherited info got %#v", info)
+	}
+
+	if info, _ := c.DescribeChecker("email"); info.Description != "" {
+		t.Fatalf("Expected the shadowing checker info got %#v", info)
+	}
+
+	if infos := c.Checkers(); infos["even"].Description != "" || infos["url"].Description == "" || len(infos) != len(v.Checkers()) {
+		t.Fatalf("Expected the checkers of both got %v", infos)
+	}
+
+	if info, _ := NewFrom(v).DescribeChecker("divisible_by"); info.Args != "<n>" {
+		t.Fatalf("Expected the merged info got %#v", info)
+	}
+
+	testCases := map[string]string{
+		"divisible_by": "invalid checker divisible_by, expecting divisible_by:<n>",
+		"min":          "invalid checker min, expecting min:<number>",
+		"emai":         `invalid checker emai, did you mean "email"?`,
+		"bogus:1":      "invalid checker bogus:1",
+	}
+
+	for tag, exp := range testCases {
+		if err := v.CheckTag(tag); !errors.Is(err, ErrInvalidChecker) || err.Error() != exp {
+			t.Fatalf("Expected %q got %v", exp, err)
+		}
+	}
+}
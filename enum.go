@@ -0,0 +1,65 @@
+package vali
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// RegisterEnum registers the values of the enum type T to the [DefaultValidator].
+// See [Validator.RegisterEnum] for details.
+func RegisterEnum[T comparable](name string, values ...T) {
+	x := make([]any, len(values))
+	for i, val := range values {
+		x[i] = val
+	}
+
+	DefaultValidator.RegisterEnum(name, x...)
+}
+
+// RegisterEnum registers the (comparable) values, i.e. the constants of an
+// enum type, under name, for the `enum:<name>` checker to validate against,
+// so that the allowed values can't drift apart from the Go ones (as a
+// one_of list would). The values being checked are converted to the type of
+// the enum (i.e. an int field to an iota enum), if of the same kind. It panics
+// with [ErrFrozen] if v is frozen (see [Validator.Freeze]).
+func (v *Validator) RegisterEnum(name string, values ...any) {
+	v.Lock()
+	defer v.Unlock()
+
+	v.mustNotBeFrozen(name)
+	v.enums[name] = values
+	v.invalidate()
+}
+
+// enum makes a checker validating the values against the enum
+// registered under name, i.e. `enum:status`.
+func (v *Validator) enum(name string) (c Checker, err error) {
+	values, ok := lookup(v, name, func(v *Validator) map[string][]any { return v.enums })
+	if !ok {
+		return nil, fmt.Errorf("unknown enum %q", name)
+	}
+
+	names := make([]string, len(values))
+	for i, x := range values {
+		names[i] = String(reflect.ValueOf(x))
+	}
+
+	return func(val reflect.Value) (err error) {
+		if !val.IsValid() || !val.Comparable() {
+			return fmt.Errorf("unsupported kind %s", val.Kind())
+		}
+
+		if len(values) > 0 {
+			if t := reflect.TypeOf(values[0]); val.Type() != t && val.Kind() == t.Kind() && val.CanConvert(t) {
+				val = val.Convert(t)
+			}
+		}
+
+		if x := Interface(val); !slices.Contains(values, x) {
+			return withHint(fmt.Errorf("%q is not one of %v", String(val), names), String(val), names)
+		}
+
+		return
+	}, nil
+}
@@ -0,0 +1,81 @@
+package vali
+
+import (
+	"errors"
+	"testing"
+)
+
+type (
+	enumStatus int
+	enumRole   string
+)
+
+const (
+	statusPending enumStatus = iota
+	statusActive
+	statusClosed
+)
+
+func (s enumStatus) String() string {
+	return [...]string{"pending", "active", "closed", "unknown"}[min(int(s), 3)]
+}
+
+func TestRegisterEnum(t *testing.T) {
+	t.Parallel()
+
+	v := New()
+	v.RegisterEnum("status", statusPending, statusActive, statusClosed)
+	v.RegisterEnum("role", enumRole("admin"), enumRole("user"))
+	v.RegisterEnum("none")
+
+	testCases := []struct {
+		val  any
+		tag  string
+		exp  string
+		hint string
+	}{
+		{statusActive, "enum:status", "", ""},
+		{enumStatus(7), "enum:status", `enum check failed: "unknown" is not one of [pending active closed]`, ""},
+		{2, "enum:status", "", ""},
+		{3, "enum:status", `enum check failed: "unknown" is not one of [pending active closed]`, ""},
+		{p(statusClosed), "enum:status", "", ""},
+		{enumRole("user"), "enum:role", "", ""},
+		{"admin", "enum:role", "", ""},
+		{"admn", "enum:role", `enum check failed: "admn" is not one of [admin user], did you mean "admin"?`, `did you mean "admin"?`},
+		{[]string{"admin"}, "enum:role", "enum check failed: unsupported kind slice", ""},
+		{"x", "enum:none", `enum check failed: "x" is not one of []`, ""},
+		{"x", "enum:bogus", `invalid checker enum:bogus: unknown enum "bogus"`, ""},
+	}
+
+	for _, tc := range testCases {
+		err := v.Child().Validate(tc.val, tc.tag)
+		if act := errString(err); act != tc.exp {
+			t.Fatalf("Expected %q got %q for %v", tc.exp, act, tc.val)
+		}
+
+		var fe *FieldError
+		if errors.As(err, &fe) && fe.Hint != tc.hint {
+			t.Fatalf("Expected hint %q got %q", tc.hint, fe.Hint)
+		}
+	}
+
+	RegisterEnum("test_role", enumRole("admin"), enumRole("user"))
+
+	if err := Validate(enumRole("root"), "enum:test_role"); err == nil {
+		t.Fatalf("Expected the enum to be registered to the default validator")
+	}
+
+	w := New()
+	w.Merge(v)
+
+	if err := w.Validate(statusClosed+1, "enum:status"); err == nil {
+		t.Fatalf("Expected the merged enum to apply")
+	}
+
+	s, err := v.JSONSchema(struct {
+		Status enumStatus `json:"status" validate:"enum:status"`
+	}{})
+	if err != nil || len(s.Properties["status"].Enum) != 3 {
+		t.Fatalf("Expected the enum values in the schema got %+v, %v", s, err)
+	}
+}
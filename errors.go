@@ -0,0 +1,237 @@
+package vali
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// FieldError is the error returned when a check fails. It wraps both
+// [ErrCheckFailed] and the error returned by the checker, so
+// [errors.Is] works with either of them.
+type FieldError struct {
+	// Err is the error returned by the checker.
+	Err error
+
+	// Check is the name of the failed check, without its arguments.
+	Check string
+
+	// Path holds the names of the fields leading to the failed value, with
+	// the indexes (or keys) of the elements appended, i.e. `Items[2]` or
+	// `Meta["owner"]`. It is empty when the failure is at the top level.
+	Path []string
+
+	// Hint suggests a fix, when one is known, i.e. the nearest allowed
+	// value, for one_of: `did you mean "pending"?`.
+	Hint string
+
+	// Locale is the locale the validation was requested in, if any (see [Opts]),
+	// for [Validator.ErrorFormatter] to render the message in.
+	Locale string
+
+	// jsonPath is the same as Path, but with the JSON names of the
+	// fields, one entry per field, index or key (see [FieldError.JSONPath]).
+	jsonPath []string
+
+	// format renders the error, when set (see [Validator.ErrorFormatter]).
+	format func(FieldError) string
+
+	// sep separates the fields of Path, when set (see [Validator.PathSep]).
+	sep string
+
+	// detail is set when the required failures tell why (see [Validator.RequiredDetail]).
+	detail bool
+}
+
+// Error implements the error interface. The message is rendered by the
+// [Validator.ErrorFormatter] of the validator that returned e, if set.
+func (e *FieldError) Error() string {
+	if e.format != nil {
+		fe := *e
+		fe.format = nil // So that it can fall back to the default message.
+
+		return e.format(fe)
+	}
+
+	msg := fmt.Sprintf("%s %s: %s", e.Check, ErrCheckFailed, e.Err)
+	if re, ok := e.Err.(*requiredError); ok && e.detail { //nolint:errorlint // not when wrapped, i.e. by msg
+		msg += " (" + re.why + ")"
+	}
+	if len(e.Path) == 0 {
+		return msg
+	}
+
+	return e.Field() + ": " + msg
+}
+
+// Unwrap allows [errors.Is] and [errors.As] to match both
+// [ErrCheckFailed] and the checker error.
+func (e *FieldError) Unwrap() []error {
+	return []error{ErrCheckFailed, e.Err}
+}
+
+// Field returns the path to the failed value, as a dot (or [Validator.PathSep])
+// separated string.
+func (e *FieldError) Field() string {
+	if e.sep == "" {
+		return strings.Join(e.Path, ".")
+	}
+
+	return strings.Join(e.Path, e.sep)
+}
+
+// StructPath returns the Go path to the failed value (i.e. "Order.Items[2].SKU"),
+// the same as [FieldError.Field].
+func (e *FieldError) StructPath() string {
+	return e.Field()
+}
+
+// JSONPath returns the path to the failed value as a JSON pointer (RFC 6901),
+// using the JSON names of the fields (i.e. "/order/items/2/sku"), so that
+// clients can point to the offending input. It is "" at the top level.
+func (e *FieldError) JSONPath() string {
+	var b strings.Builder
+
+	for _, seg := range e.jsonPath {
+		b.WriteString("/" + jsonPointerEscaper.Replace(seg))
+	}
+
+	return b.String()
+}
+
+// Errors holds the check failures collected when [Validator.MaxErrors] is
+// set, in the struct declaration order (unless reordered, see [Validator.CheckOrder]),
+// with the map elements sorted by key.
+type Errors []*FieldError
+
+// Error implements the error interface.
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap allows [errors.Is] and [errors.As] to match any of the failures.
+func (e Errors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+
+	return errs
+}
+
+// ByField groups the failures by their field path (see [FieldError.Field]),
+// i.e. for rendering the messages under each input of a form.
+func (e Errors) ByField() (fields map[string][]*FieldError) {
+	fields = map[string][]*FieldError{}
+	for _, err := range e {
+		fields[err.Field()] = append(fields[err.Field()], err)
+	}
+
+	return
+}
+
+// BatchErrors holds the errors of the items that failed the validation
+// (see [Validator.ValidateAll]), by their index.
+type BatchErrors map[int]error
+
+// Error implements the error interface, listing the errors by index.
+func (e BatchErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, i := range slices.Sorted(maps.Keys(e)) {
+		msgs = append(msgs, fmt.Sprintf("[%d]: %v", i, e[i]))
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap allows [errors.Is] and [errors.As] to match any of the errors.
+func (e BatchErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, i := range slices.Sorted(maps.Keys(e)) {
+		errs = append(errs, e[i])
+	}
+
+	return errs
+}
+
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// newFieldError wraps err in a [FieldError], replacing its message
+// with msg, if set (while still wrapping it).
+func newFieldError(err error, check, msg string, scope, jsonPath []string) (fe *FieldError) {
+	fe = &FieldError{Err: err, Check: check, Path: slices.Clone(scope), jsonPath: slices.Clone(jsonPath)}
+
+	if he := (*hintError)(nil); errors.As(err, &he) {
+		fe.Hint = he.hint
+	}
+
+	if msg != "" {
+		fe.Err = &messageError{msg: msg, err: err}
+	}
+
+	return
+}
+
+// requiredError is an [ErrRequired] telling why the value is missing (see [ErrAbsent]).
+type requiredError struct {
+	why string
+}
+
+func (e *requiredError) Error() string {
+	return ErrRequired.Error()
+}
+
+func (e *requiredError) Unwrap() error {
+	return ErrRequired
+}
+
+// messageError replaces the message of err with a custom one.
+type messageError struct {
+	msg string
+	err error
+}
+
+func (e *messageError) Error() string {
+	return e.msg
+}
+
+func (e *messageError) Unwrap() error {
+	return e.err
+}
+
+// hintError adds a hint (see [FieldError.Hint]) to the message of err.
+type hintError struct {
+	err  error
+	hint string
+}
+
+func (e *hintError) Error() string {
+	return e.err.Error() + ", " + e.hint
+}
+
+func (e *hintError) Unwrap() error {
+	return e.err
+}
+
+// altErrors holds the errors of all the failed alternatives of a check.
+type altErrors []error
+
+func (e altErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, " or ")
+}
+
+func (e altErrors) Unwrap() []error {
+	return e
+}
@@ -0,0 +1,222 @@
+package vali
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFieldError(t *testing.T) {
+	t.Parallel()
+
+	s := struct {
+		Foo struct {
+			Bar string `validate:"required"`
+		}
+	}{}
+
+	err := Validate(s)
+
+	var fe *FieldError
+	if !errors.As(err, &fe) {
+		t.Fatalf("Expected a *FieldError got %T", err)
+	}
+
+	if fe.Check != "required" || fe.Field() != "Foo.Bar" || !errors.Is(fe.Err, ErrRequired) {
+		t.Fatalf("Unexpected field error %#v", fe)
+	}
+
+	if !errors.Is(err, ErrCheckFailed) || !errors.Is(err, ErrRequired) {
+		t.Fatalf("Expected %v to wrap both %v and %v", err, ErrCheckFailed, ErrRequired)
+	}
+
+	if err = Validate("", "required"); !errors.As(err, &fe) || fe.Field() != "" {
+		t.Fatalf("Expected a top level *FieldError got %#v", err)
+	}
+}
+
+func TestErrorsByField(t *testing.T) {
+	t.Parallel()
+
+	v := New()
+	v.MaxErrors = -1
+
+	err := v.ValidateValues(url.Values{"tag": {"a", "bb", "c"}, "name": {"x"}},
+		map[string]string{"tag": "min:2", "name": "min:3", "page": "required"})
+
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Expected Errors got %T", err)
+	}
+
+	act := map[string][]string{}
+	for field, fx := range errs.ByField() {
+		for _, fe := range fx {
+			act[field] = append(act[field], fe.Err.Error())
+		}
+	}
+
+	exp := map[string][]string{
+		"name": {"len 1 is less than 3"},
+		"page": {"value missing"},
+		"tag":  {"len 1 is less than 2", "len 1 is less than 2"},
+	}
+
+	if !reflect.DeepEqual(act, exp) {
+		t.Fatalf("Expected %v got %v", exp, act)
+	}
+
+	fields := []string{errs[0].Field(), errs[1].Field(), errs[2].Field(), errs[3].Field()}
+	if exp := []string{"name", "page", "tag", "tag"}; !slices.Equal(fields, exp) {
+		t.Fatalf("Expected %v got %v", exp, fields)
+	}
+
+	if !errors.Is(err, ErrRequired) {
+		t.Fatalf("Expected %v to wrap %v", err, ErrRequired)
+	}
+
+	v.MaxErrors = 1
+
+	if err = v.ValidateValues(nil, map[string]string{"a": "required", "b": "required"}); !errors.As(err, &errs) ||
+		len(errs) != 1 || !errors.Is(err, ErrTooManyErrors) {
+		t.Fatalf("Expected one error and %v got %v", ErrTooManyErrors, err)
+	}
+}
+
+func TestFieldErrorPaths(t *testing.T) {
+	t.Parallel()
+
+	type (
+		base struct {
+			ID string `json:"id" validate:"required"`
+		}
+
+		item struct {
+			SKU string `json:"sku" validate:"required"`
+		}
+
+		order struct {
+			base
+
+			Items []item          `json:"items"`
+			Meta  map[string]item `json:"meta,omitempty"`
+			Note  string          `validate:"max:3"`
+		}
+
+		request struct {
+			Order order `json:"order"`
+		}
+	)
+
+	ok := item{SKU: "x"}
+
+	testCases := []struct {
+		val              request
+		structPath, path string
+	}{
+		{request{order{Items: []item{ok}}}, "Order.base.ID", "/order/id"},
+		{request{order{base{"1"}, []item{ok, ok, {}}, nil, ""}}, "Order.Items[2].SKU", "/order/items/2/sku"},
+		{request{order{base{"1"}, nil, map[string]item{"a/b~": {}}, ""}}, `Order.Meta["a/b~"].SKU`, "/order/meta/a~1b~0/sku"},
+		{request{order{base{"1"}, nil, nil, "long"}}, "Order.Note", "/order/Note"},
+	}
+
+	for _, tc := range testCases {
+		var fe *FieldError
+		if err := Validate(tc.val); !errors.As(err, &fe) {
+			t.Fatalf("Expected a *FieldError got %v", err)
+		}
+
+		if fe.StructPath() != tc.structPath || fe.JSONPath() != tc.path {
+			t.Fatalf("Expected %q, %q got %q, %q", tc.structPath, tc.path, fe.StructPath(), fe.JSONPath())
+		}
+	}
+
+	var fe *FieldError
+	if err := Validate("", "required"); !errors.As(err, &fe) || fe.JSONPath() != "" {
+		t.Fatalf("Expected a top level *FieldError got %v", err)
+	}
+}
+
+func TestPathSep(t *testing.T) {
+	t.Parallel()
+
+	type (
+		item struct {
+			SKU string `json:"sku" validate:"required"`
+		}
+
+		order struct {
+			Items  []item          `json:"items"`
+			ByID   map[int]item    `json:"by_id"`
+			ByName map[string]item `json:"by_name"`
+		}
+	)
+
+	v := New()
+	v.PathSep = "/"
+
+	testCases := []struct {
+		data string
+		exp  string
+	}{
+		{`{"items": [{"sku": "x"}, {}]}`, "Items[1]/SKU"},
+		{`{"by_id": {"7": {}}}`, "ByID[7]/SKU"},
+		{`{"by_name": {"7": {}}}`, `ByName["7"]/SKU`},
+	}
+
+	for _, tc := range testCases {
+		var (
+			o  order
+			fe *FieldError
+		)
+
+		if err := v.ValidateJSON([]byte(tc.data), &o); !errors.As(err, &fe) || fe.Field() != tc.exp {
+			t.Fatalf("Expected %q got %v", tc.exp, err)
+		}
+
+		if exp := tc.exp + ": required check failed: value missing"; fe.Error() != exp {
+			t.Fatalf("Expected %q got %q", exp, fe.Error())
+		}
+	}
+
+	var o order
+	if err := v.ValidateJSON([]byte(`{"by_id": {"7": {"sku": ""}}}`), &o); err != nil {
+		t.Fatalf("Expected the int keyed elements to be present got %v", err)
+	}
+}
+
+func TestErrorFormatter(t *testing.T) {
+	t.Parallel()
+
+	v := New()
+	v.ErrorFormatter = func(fe FieldError) string {
+		if fe.Check == "required" {
+			return fmt.Sprintf("%s is required.", strings.ToLower(fe.Field()))
+		}
+
+		return "Invalid input: " + fe.Error()
+	}
+
+	s := struct {
+		Name  string `validate:"required"`
+		Email string `validate:"email"`
+	}{Email: "x"}
+
+	if err := v.Validate(s); err.Error() != "name is required." || !errors.Is(err, ErrRequired) {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	s.Name, v.MaxErrors = "x", -1
+
+	if err := v.Child().Validate(s); err.Error() != `Invalid input: Email: email check failed: "x" is not a valid email address` {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if err := Validate(s); err.Error() != `Email: email check failed: "x" is not a valid email address` {
+		t.Fatalf("Expected the default message got %v", err)
+	}
+}
@@ -1,15 +1,16 @@
 package vali_test
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/alexaandru/vali"
 )
 
-type foo struct{}
+type foo string
 
-func (foo) Foo() string {
-	return "hello world"
+func (f foo) Foo() string {
+	return "hello " + string(f)
 }
 
 func ExampleValidator_Validate() {
@@ -84,17 +85,27 @@ func ExampleValidator_Validate_interface() {
 	}
 
 	s := struct {
-		F fooer `validate:"required"`
+		F fooer `validate:"required,min:3"`
 	}{}
 
 	err := vali.Validate(s)
 	fmt.Println(err) // This should err.
 
-	s.F = foo{}
+	s.F = (*foo)(nil)
+	err = vali.Validate(s)
+	fmt.Println(err) // So should this, the dynamic value is checked.
+
+	s.F = foo("go")
+	err = vali.Validate(s)
+	fmt.Println(err) // And this.
+
+	s.F = foo("world")
 	err = vali.Validate(s)
 	fmt.Println(err) // This should not.
 
 	// Output: F: required check failed: value missing
+	// F: required check failed: value missing
+	// F: min check failed: len 2 is less than 3
 	// <nil>
 }
 
@@ -170,3 +181,24 @@ func ExampleValidator_Validate_ssn_npi() {
 	// Valid NPI: <nil>
 	// Invalid NPI: true
 }
+
+func ExampleJSONSchema() {
+	type user struct {
+		Name string `json:"name" validate:"required,max:32"`
+		Role string `json:"role" validate:"one_of:admin|user"`
+	}
+
+	s, err := vali.JSONSchema(user{})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	b, err := json.Marshal(s)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(string(b))
+
+	// Output: {"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object","properties":{"name":{"type":"string","minLength":1,"maxLength":32},"role":{"type":"string","enum":["admin","user"]}},"required":["name"]}
+}
@@ -0,0 +1,123 @@
+package vali
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+type (
+	// Plan is the resolved validation plan of a struct type (see [Validator.Explain]),
+	// one entry per validated field, in the order they are validated.
+	Plan []FieldPlan
+
+	// FieldPlan is the resolved plan of a single field.
+	FieldPlan struct {
+		// Field is the path to the field, with `[]` marking the collections.
+		Field string `json:"field"`
+		Type  string `json:"type"`
+
+		// Tag is the tag of the field, as written, and Source tells whether
+		// it comes from the struct "tag" or from the external "rules" (see
+		// [Validator.LoadRules]), which take precedence.
+		Tag    string `json:"tag"`
+		Source string `json:"source"`
+
+		// Order is the position of the field in the validation order (see
+		// the order modifier), relative to its siblings, 0 by default.
+		Order int `json:"order,omitempty"`
+
+		Checks []CheckPlan `json:"checks"`
+	}
+
+	// CheckPlan is the resolved plan of a single check.
+	CheckPlan struct {
+		// Name is the name the check resolves to, i.e. `required`
+		// for `Required` (see [Validator.CaseInsensitive]), or the
+		// whole alternation, for the alternative checks.
+		Name string `json:"name"`
+
+		// Args is passed to the checker maker, as is (unquoted).
+		Args string `json:"args,omitempty"`
+
+		// Groups holds the groups the check is restricted to (see [Validator.GroupSep]).
+		Groups []string `json:"groups,omitempty"`
+
+		// SkipsZero tells whether the check is skipped for the zero
+		// values (see [Validator.DontSkipZeroChecks] and omitempty).
+		SkipsZero bool `json:"skips_zero"`
+	}
+)
+
+// Explain explains the validation plan of t using the [DefaultValidator].
+// See [Validator.Explain] for details.
+func Explain(t reflect.Type) (Plan, error) {
+	return DefaultValidator.Explain(t)
+}
+
+// Explain walks the struct type t, the same way as [Validator.CheckStruct]
+// does, and returns the plan the validation of its values follows: the
+// fields, in order, with their checks resolved as per the settings of v,
+// i.e. to find out why a rule isn't firing. It fails if any tag is invalid.
+func (v *Validator) Explain(t reflect.Type) (p Plan, err error) {
+	err = v.walkTags(t, func(path string, f reflect.StructField, tag string) (err error) {
+		if err = v.CheckTag(tag); err != nil {
+			return
+		}
+
+		fp := FieldPlan{Field: path, Type: f.Type.String(), Tag: tag, Source: "tag"}
+		if tag != v.fieldTag(f, nil) {
+			fp.Source = "rules"
+		}
+
+		checks, err := v.splitChecks(tag)
+		if err != nil {
+			return
+		}
+
+		omitEmpty, explicit := false, v.ExplicitOmitEmpty
+
+		for _, check := range checks {
+			check, groups := v.cutGroups(check)
+			if check = strings.TrimSpace(check); check == "" {
+				continue
+			}
+
+			if v.CaseInsensitive {
+				check = v.foldNames(check)
+			}
+
+			slices.Reverse(groups) // As written.
+
+			cp := CheckPlan{Name: check, Groups: groups}
+			if alts := v.alternatives(check); len(alts) < 2 {
+				name, args, _ := strings.Cut(check, v.CheckArgSep)
+				cp.Name, cp.Args = name, unquote(args)
+			}
+
+			switch cp.Name {
+			case "omitempty":
+				omitEmpty = true
+			case "omitnil":
+				explicit = true
+			case "order":
+				fp.Order, _ = ParseIntArg(cp.Args)
+			}
+
+			fp.Checks = append(fp.Checks, cp)
+		}
+
+		for i, cp := range fp.Checks {
+			fp.Checks[i].SkipsZero = omitEmpty || (!explicit && !v.dontSkipZero(cp.Name))
+		}
+
+		p = append(p, fp)
+
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
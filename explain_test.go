@@ -0,0 +1,61 @@
+package vali
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+
+	type (
+		item struct {
+			SKU string `validate:"required"`
+		}
+
+		order struct {
+			Note  string `validate:"Max:3"`
+			ID    string `validate:"omitempty,uuid"`
+			Kind  string `validate:"required@create,one_of:'a|b',order:-1"`
+			Items []item `validate:"min:1"`
+			Ref   string `validate:"ipv4|ipv6"`
+			Other string
+		}
+	)
+
+	v := New()
+	v.CaseInsensitive = true
+	v.LoadRules(Rules{reflect.TypeFor[order]().String(): {"Other": "max:5"}})
+
+	p, err := v.Explain(reflect.TypeFor[order]())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := Plan{
+		{Field: "Kind", Type: "string", Tag: "required@create,one_of:'a|b',order:-1", Source: "tag", Order: -1, Checks: []CheckPlan{
+			{Name: "required", Groups: []string{"create"}},
+			{Name: "one_of", Args: "a|b", SkipsZero: true},
+			{Name: "order", Args: "-1", SkipsZero: true},
+		}},
+		{Field: "Note", Type: "string", Tag: "Max:3", Source: "tag", Checks: []CheckPlan{{Name: "max", Args: "3"}}},
+		{Field: "ID", Type: "string", Tag: "omitempty,uuid", Source: "tag", Checks: []CheckPlan{
+			{Name: "omitempty", SkipsZero: true},
+			{Name: "uuid", SkipsZero: true},
+		}},
+		{Field: "Items", Type: "[]vali.item", Tag: "min:1", Source: "tag", Checks: []CheckPlan{{Name: "min", Args: "1"}}},
+		{Field: "Items[].SKU", Type: "string", Tag: "required", Source: "tag", Checks: []CheckPlan{{Name: "required"}}},
+		{Field: "Ref", Type: "string", Tag: "ipv4|ipv6", Source: "tag", Checks: []CheckPlan{{Name: "ipv4|ipv6", SkipsZero: true}}},
+		{Field: "Other", Type: "string", Tag: "max:5", Source: "rules", Checks: []CheckPlan{{Name: "max", Args: "5"}}},
+	}
+
+	if !reflect.DeepEqual(p, exp) {
+		t.Fatalf("Expected\n%+v\ngot\n%+v", exp, p)
+	}
+
+	if _, err = Explain(reflect.TypeFor[struct {
+		A string `validate:"bogus"`
+	}]()); err == nil {
+		t.Fatal("Expected the invalid tag to fail")
+	}
+}
@@ -0,0 +1,379 @@
+package vali
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math/big"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var errNotComparable = errors.New("values are not comparable")
+
+// expr compiles a boolean expression over the fields of the enclosing
+// struct (or of the value itself, at the top level), i.e. `expr:Min <= Max`
+// or `expr:Quantity*Price == Total`.
+//
+// The expressions use the Go syntax, restricted to: field names (including
+// nested ones, i.e. Address.Zip), number, string and bool literals, nil,
+// the arithmetic (+ - * / %), comparison and logical operators and len().
+// Integers use integer arithmetic (i.e. 7/2 == 3), the other numbers exact
+// rational arithmetic (i.e. 3*0.1 == 0.3) and times [time.Time.Compare].
+func expr(args string) (c Checker, err error) {
+	x, err := parser.ParseExpr(args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression %q: %w", args, err)
+	}
+
+	return func(v reflect.Value) (err error) {
+		f, ok := Interface(v).(Field)
+		if !ok {
+			return errors.New("no enclosing struct")
+		}
+
+		res, err := evalExpr(x, indirect(f.Parent))
+		if err != nil {
+			return
+		}
+
+		switch ok, isBool := res.(bool); {
+		case !isBool:
+			return fmt.Errorf("%q is not a boolean expression", args)
+		case !ok:
+			return fmt.Errorf("%q is false", args)
+		default:
+			return
+		}
+	}, nil
+}
+
+//nolint:gocognit,cyclop // it's a long, but flat, switch
+func evalExpr(x ast.Expr, root reflect.Value) (res any, err error) {
+	switch x := x.(type) {
+	case *ast.ParenExpr:
+		return evalExpr(x.X, root)
+	case *ast.BasicLit:
+		switch x.Kind { //nolint:exhaustive // the rest are unsupported
+		case token.INT:
+			if n, ok := new(big.Int).SetString(x.Value, 0); ok {
+				return n, nil
+			}
+		case token.FLOAT:
+			if n, ok := new(big.Rat).SetString(x.Value); ok {
+				return n, nil
+			}
+		case token.STRING:
+			return strconv.Unquote(x.Value)
+		}
+
+		return nil, fmt.Errorf("unsupported literal %s", x.Value)
+	case *ast.Ident:
+		switch x.Name {
+		case "true", "false":
+			return x.Name == "true", nil
+		case "nil":
+			return
+		}
+
+		return exprField(x, root)
+	case *ast.SelectorExpr:
+		return exprField(x, root)
+	case *ast.CallExpr:
+		if fn, ok := x.Fun.(*ast.Ident); !ok || fn.Name != "len" || len(x.Args) != 1 {
+			return nil, errors.New("only len(x) calls are supported")
+		}
+
+		var fv reflect.Value
+
+		if fv, err = exprFieldValue(x.Args[0], root); err != nil {
+			return
+		}
+
+		switch fv.Kind() { //nolint:exhaustive // only these have a length
+		case reflect.Invalid:
+			return new(big.Int), nil
+		case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
+			return big.NewInt(int64(fv.Len())), nil
+		default:
+			return nil, fmt.Errorf("invalid argument for len: %s", fv.Kind())
+		}
+	case *ast.UnaryExpr:
+		if res, err = evalExpr(x.X, root); err != nil {
+			return
+		}
+
+		switch y := res.(type) {
+		case bool:
+			if x.Op == token.NOT {
+				return !y, nil
+			}
+		case *big.Int:
+			switch x.Op { //nolint:exhaustive // the rest are unsupported
+			case token.SUB:
+				return new(big.Int).Neg(y), nil
+			case token.ADD:
+				return y, nil
+			}
+		case *big.Rat:
+			switch x.Op { //nolint:exhaustive // the rest are unsupported
+			case token.SUB:
+				return new(big.Rat).Neg(y), nil
+			case token.ADD:
+				return y, nil
+			}
+		}
+
+		return nil, fmt.Errorf("invalid operation %s%v", x.Op, res)
+	case *ast.BinaryExpr:
+		return evalBinary(x, root)
+	default:
+		return nil, fmt.Errorf("unsupported expression %T", x)
+	}
+}
+
+//nolint:gocognit,cyclop // it's a long, but flat, switch
+func evalBinary(x *ast.BinaryExpr, root reflect.Value) (res any, err error) {
+	a, err := evalExpr(x.X, root)
+	if err != nil {
+		return
+	}
+
+	// Short-circuit evaluation for the logical operators.
+	if x.Op == token.LAND || x.Op == token.LOR {
+		ok, isBool := a.(bool)
+		if !isBool {
+			return nil, fmt.Errorf("invalid operation %v %s", a, x.Op)
+		}
+
+		if ok == (x.Op == token.LOR) {
+			return ok, nil
+		}
+	}
+
+	b, err := evalExpr(x.Y, root)
+	if err != nil {
+		return
+	}
+
+	switch x.Op { //nolint:exhaustive // the rest are unsupported
+	case token.LAND, token.LOR:
+		if ok, isBool := b.(bool); isBool {
+			return ok, nil
+		}
+	case token.EQL, token.NEQ:
+		c, err := exprCompare(a, b)
+		if errors.Is(err, errNotComparable) {
+			return (a == b) == (x.Op == token.EQL), nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		return (c == 0) == (x.Op == token.EQL), nil
+	case token.LSS, token.LEQ, token.GTR, token.GEQ:
+		c, err := exprCompare(a, b)
+		if err != nil {
+			return nil, fmt.Errorf("invalid operation %v %s %v: %w", a, x.Op, b, err)
+		}
+
+		switch x.Op { //nolint:exhaustive // only the ones above
+		case token.LSS:
+			return c < 0, nil
+		case token.LEQ:
+			return c <= 0, nil
+		case token.GTR:
+			return c > 0, nil
+		default:
+			return c >= 0, nil
+		}
+	case token.ADD, token.SUB, token.MUL, token.QUO, token.REM:
+		if s1, ok := a.(string); ok && x.Op == token.ADD {
+			if s2, ok := b.(string); ok {
+				return s1 + s2, nil
+			}
+		}
+
+		if i1, ok := a.(*big.Int); ok {
+			if i2, ok := b.(*big.Int); ok {
+				return exprIntArith(i1, i2, x.Op)
+			}
+		}
+
+		r1, ok1 := exprRat(a)
+		r2, ok2 := exprRat(b)
+
+		if ok1 && ok2 {
+			return exprRatArith(r1, r2, x.Op)
+		}
+	}
+
+	return nil, fmt.Errorf("invalid operation %v %s %v", a, x.Op, b)
+}
+
+// exprIntArith does the integer arithmetic, truncating the
+// divisions, the same as Go does.
+func exprIntArith(a, b *big.Int, op token.Token) (res any, err error) {
+	switch op { //nolint:exhaustive // only the arithmetic ones
+	case token.ADD:
+		return new(big.Int).Add(a, b), nil
+	case token.SUB:
+		return new(big.Int).Sub(a, b), nil
+	case token.MUL:
+		return new(big.Int).Mul(a, b), nil
+	case token.QUO, token.REM:
+		if b.Sign() == 0 {
+			return nil, errors.New("division by zero")
+		}
+
+		if op == token.QUO {
+			return new(big.Int).Quo(a, b), nil
+		}
+
+		return new(big.Int).Rem(a, b), nil
+	default:
+		return nil, fmt.Errorf("unsupported operator %s", op)
+	}
+}
+
+// exprRatArith does the (exact) rational arithmetic.
+func exprRatArith(a, b *big.Rat, op token.Token) (res any, err error) {
+	switch op { //nolint:exhaustive // only the arithmetic ones
+	case token.ADD:
+		return new(big.Rat).Add(a, b), nil
+	case token.SUB:
+		return new(big.Rat).Sub(a, b), nil
+	case token.MUL:
+		return new(big.Rat).Mul(a, b), nil
+	case token.QUO:
+		if b.Sign() == 0 {
+			return nil, errors.New("division by zero")
+		}
+
+		return new(big.Rat).Quo(a, b), nil
+	case token.REM:
+		// The remainder works on the integer parts, so that `A % 0.5` can't panic.
+		return exprIntArith(ratInt(a), ratInt(b), op)
+	default:
+		return nil, fmt.Errorf("unsupported operator %s", op)
+	}
+}
+
+// exprRat converts the numbers to [big.Rat], ok is false for anything else.
+func exprRat(n any) (r *big.Rat, ok bool) {
+	switch n := n.(type) {
+	case *big.Int:
+		return new(big.Rat).SetInt(n), true
+	case *big.Rat:
+		return n, true
+	default:
+		return
+	}
+}
+
+// ratInt returns the integer part of r.
+func ratInt(r *big.Rat) *big.Int {
+	return new(big.Int).Quo(r.Num(), r.Denom())
+}
+
+func exprCompare(a, b any) (int, error) {
+	if r1, ok := exprRat(a); ok {
+		if r2, ok := exprRat(b); ok {
+			return r1.Cmp(r2), nil
+		}
+
+		return 0, errNotComparable
+	}
+
+	switch a := a.(type) {
+	case string:
+		if b, ok := b.(string); ok {
+			return cmp.Compare(a, b), nil
+		}
+	case time.Time:
+		if b, ok := b.(time.Time); ok {
+			return a.Compare(b), nil
+		}
+	}
+
+	return 0, errNotComparable
+}
+
+// exprField returns the value of the field x refers to, converted
+// to one of the types the expressions work with.
+func exprField(x ast.Expr, root reflect.Value) (res any, err error) {
+	fv, err := exprFieldValue(x, root)
+	if err != nil || !fv.IsValid() {
+		return
+	}
+
+	switch {
+	case fv.Type() == timeType:
+		return Interface(fv), nil
+	case fv.CanInt():
+		return big.NewInt(fv.Int()), nil
+	case fv.CanUint():
+		return new(big.Int).SetUint64(fv.Uint()), nil
+	case fv.CanFloat():
+		return exprFloat(fv)
+	case fv.Kind() == reflect.String:
+		return fv.String(), nil
+	case fv.Kind() == reflect.Bool:
+		return fv.Bool(), nil
+	default:
+		return nil, fmt.Errorf("unsupported field type %s", fv.Type())
+	}
+}
+
+// exprFloat converts the float fv to the [big.Rat] of its shortest decimal
+// representation, so that i.e. a 0.1 field is exactly 1/10.
+func exprFloat(fv reflect.Value) (res any, err error) {
+	bits := 64
+	if fv.Kind() == reflect.Float32 {
+		bits = 32
+	}
+
+	n, ok := new(big.Rat).SetString(strconv.FormatFloat(fv.Float(), 'g', -1, bits))
+	if !ok {
+		return nil, fmt.Errorf("invalid number %v", fv.Float())
+	}
+
+	return n, nil
+}
+
+// exprFieldValue resolves the (possibly nested) field x refers to.
+func exprFieldValue(x ast.Expr, root reflect.Value) (fv reflect.Value, err error) {
+	switch x := x.(type) {
+	case *ast.Ident:
+		return exprLookup(root, x.Name)
+	case *ast.SelectorExpr:
+		if fv, err = exprFieldValue(x.X, root); err != nil {
+			return
+		}
+
+		return exprLookup(fv, x.Sel.Name)
+	default:
+		return fv, fmt.Errorf("unsupported expression %T", x)
+	}
+}
+
+// exprLookup returns the (indirected) name field of parent,
+// or the zero [reflect.Value] if parent is nil.
+func exprLookup(parent reflect.Value, name string) (fv reflect.Value, err error) {
+	if !parent.IsValid() {
+		return
+	}
+
+	if parent.Kind() != reflect.Struct {
+		return fv, fmt.Errorf("cannot access %s of %s", name, parent.Kind())
+	}
+
+	if _, ok := parent.Type().FieldByName(name); !ok {
+		return fv, fmt.Errorf("unknown field %s", name)
+	}
+
+	return indirect(parent.FieldByName(name)), nil
+}
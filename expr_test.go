@@ -0,0 +1,121 @@
+package vali
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestExpr(t *testing.T) {
+	t.Parallel()
+
+	type (
+		period struct {
+			Start, End time.Time
+		}
+
+		order struct {
+			Min      int  `validate:"expr:Min <= Max"`
+			Max      int  `validate:"required"`
+			Quantity uint `validate:"expr:Quantity*Price == Total"`
+			Price    float64
+			Total    float64
+			Code     string   `validate:"expr:len(Code) == 3 || Code == \"none\""`
+			Note     *string  `validate:"expr:Note == nil || len(Note) > 0 && Code != \"none\""`
+			Tags     []string `validate:"expr:!(len(Tags) > 2)"`
+			Period   period   `validate:"expr:Period.Start < Period.End"`
+		}
+	)
+
+	now := time.Now()
+	valid := order{
+		Min: 1, Max: 2, Quantity: 3, Price: 1.5, Total: 4.5, Code: "ABC",
+		Period: period{Start: now, End: now.Add(time.Hour)},
+	}
+
+	testCases := []struct {
+		mod func(*order)
+		exp string
+	}{
+		{func(*order) {}, ""},
+		{func(o *order) { o.Min = 3 }, `Min: expr check failed: "Min <= Max" is false`},
+		{func(o *order) { o.Min, o.Max = 0, 0 }, "Max: required check failed: value missing"},
+		{func(o *order) { o.Total = 5 }, `Quantity: expr check failed: "Quantity*Price == Total" is false`},
+		{func(o *order) { o.Price, o.Total = 0.1, 0.3 }, ""},
+		{func(o *order) { o.Price, o.Total = 0.1, 0.30000000000000004 }, `Quantity: expr check failed: "Quantity*Price == Total" is false`},
+		{func(o *order) { o.Code = "AB" }, `Code: expr check failed: "len(Code) == 3 || Code == \"none\"" is false`},
+		{func(o *order) { o.Code = "none" }, ""},
+		{func(o *order) { o.Note = p("x") }, ""},
+		{func(o *order) { o.Note, o.Code = p("x"), "none" }, `Note: expr check failed: "Note == nil || len(Note) > 0 && Code != \"none\"" is false`},
+		{func(o *order) { o.Tags = []string{"a", "b", "c"} }, `Tags: expr check failed: "!(len(Tags) > 2)" is false`},
+		{func(o *order) { o.Period.End = now }, `Period: expr check failed: "Period.Start < Period.End" is false`},
+	}
+
+	for _, tc := range testCases {
+		o := valid
+		tc.mod(&o)
+
+		if act := errString(Validate(o)); act != tc.exp {
+			t.Fatalf("Expected %q got %q", tc.exp, act)
+		}
+	}
+}
+
+func TestExprTopLevel(t *testing.T) {
+	t.Parallel()
+
+	type (
+		inner struct{ A int }
+		pair  struct {
+			A, B  int
+			Big   uint64
+			F     float32
+			S     string
+			In    *inner
+			Items map[string]int
+		}
+	)
+
+	val := pair{A: 1, B: 2, Big: math.MaxUint64, F: 0.1, S: "x", Items: map[string]int{"a": 1}}
+
+	testCases := []struct {
+		tag string
+		exp string
+	}{
+		{"expr:A < B", ""},
+		{"expr:A + 1 == B && -A == 1 - B", ""},
+		{"expr:B / A == 2 && B % 2 == 0", ""},
+		{"expr:A / B == 0 && A / 2.0 == 0.5 && -A % B == -1", ""},
+		{"expr:F * 3 == 0.3 && 0.1 * 3 == 0.3 && F + 0.2 != 0.30000000000000004", ""},
+		{"expr:Big == 18446744073709551615 && Big + 1 > Big && Big - 1 < Big", ""},
+		{"expr:A + 1.5 == B", `expr check failed: "A + 1.5 == B" is false`},
+		{"expr:S + \"y\" == \"xy\" && S != \"\"", ""},
+		{"expr:In == nil && In.A == nil", ""},
+		{"expr:len(Items) == 1 && len(In) == 0", ""},
+		{"!expr:A > B", ""},
+		{"!expr:A < B", `!expr check failed: "{1 2 18446744073709551615 0.1 x <nil> map[a:1]}" must not pass expr:A < B`},
+		{"expr:A", `expr check failed: "A" is not a boolean expression`},
+		{"expr:Nope > 1", "expr check failed: unknown field Nope"},
+		{"expr:S.X > 1", "expr check failed: cannot access X of string"},
+		{"expr:A < S", "expr check failed: invalid operation 1 < x: values are not comparable"},
+		{"expr:A / 0 > 1", "expr check failed: division by zero"},
+		{"expr:A % 0 > 1", "expr check failed: division by zero"},
+		{"expr:A % 0.5 > 1", "expr check failed: division by zero"},
+		{"expr:A - S > 1", "expr check failed: invalid operation 1 - x"},
+		{"expr:A && B", "expr check failed: invalid operation 1 &&"},
+		{"expr:true && B", "expr check failed: invalid operation true && 2"},
+		{"expr:!A", "expr check failed: invalid operation !1"},
+		{"expr:cap(Items) > 0", "expr check failed: only len(x) calls are supported"},
+		{"expr:len(A) > 0", "expr check failed: invalid argument for len: int"},
+		{"expr:Items[\"a\"] == 1", "expr check failed: unsupported expression *ast.IndexExpr"},
+		{"expr:len(1) == 1", "expr check failed: unsupported expression *ast.BasicLit"},
+		{"expr:A == 'a'", "expr check failed: unsupported literal 'a'"},
+		{"expr:A <", `invalid checker expr:A <: invalid expression "A <": 1:4: expected operand, found 'EOF'`},
+	}
+
+	for _, tc := range testCases {
+		if act := errString(Validate(val, tc.tag)); act != tc.exp {
+			t.Fatalf("Expected %q got %q for %q", tc.exp, act, tc.tag)
+		}
+	}
+}
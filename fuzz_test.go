@@ -0,0 +1,90 @@
+package vali
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func FuzzParse(f *testing.F) {
+	for _, tag := range []string{
+		"required,uuid", "one_of:a|b|c", "ipv4|ipv6", "!alpha", "not:not:alpha",
+		"regex:'^\\d{1,3}$'", "regex:^\\d{1\\,3}$", "between:1|2", "expr:A < B",
+		"msg:'oops, again',min:3", "omitempty,csv:3|;", ",,,:", "'", "\\", "!", "|", "!|!",
+	} {
+		f.Add(tag, ",", ":", "|")
+	}
+
+	f.Fuzz(func(t *testing.T, tag, sep, argSep, orSep string) {
+		v := New()
+		v.CheckSep, v.CheckArgSep, v.OrSep = sep, argSep, orSep
+
+		_ = v.CheckTag(tag)
+		_ = v.Validate("foo", tag)
+		_ = v.Validate(struct{ A, B int }{1, 2}, tag)
+	})
+}
+
+func FuzzSplitChecks(f *testing.F) {
+	for _, tag := range []string{"a,b", `a\,b`, "regex:'a,b',c", "regex:'a", "x:'',y", "é,ü:ß"} {
+		f.Add(tag, ",", ":")
+	}
+
+	f.Fuzz(func(t *testing.T, tag, sep, argSep string) {
+		v := New()
+		v.CheckSep, v.CheckArgSep = sep, argSep
+
+		checks, err := v.splitChecks(tag)
+		if err == nil && sep != "" && len(checks) > len(tag)/len(sep)+1 {
+			t.Fatalf("Too many checks %d for %q", len(checks), tag)
+		}
+	})
+}
+
+func FuzzSizeCmp(f *testing.F) {
+	for _, arg := range []string{"0", "-1", "3", "1e3", "now-24h", "2020-01-01T00:00:00Z", "x", ""} {
+		f.Add(arg, "foo", int64(5), 1.5)
+	}
+
+	f.Fuzz(func(t *testing.T, arg, s string, n int64, x float64) {
+		for _, mk := range []CheckerMaker{Eq, Ne, Min, Max} {
+			c, err := mk(arg)
+			if err != nil {
+				continue
+			}
+
+			for _, val := range []any{s, n, uint(n), x, float32(x), []byte(s), map[string]int{s: 1}, time.Unix(n, 0), &s, nil} {
+				_ = c(reflect.ValueOf(val))
+			}
+		}
+	})
+}
+
+func TestParseHostile(t *testing.T) {
+	t.Parallel()
+
+	for _, tag := range []string{
+		strings.Repeat("!", 1<<20) + "alpha",
+		strings.Repeat("not:", 1<<18) + "alpha",
+		strings.Repeat("!not:", 1<<18) + "alpha",
+		strings.Repeat("a|", 1<<16) + "alpha",
+		"regex:" + strings.Repeat("(", 1<<16),
+		"expr:" + strings.Repeat("(", 1<<16) + "A" + strings.Repeat(")", 1<<16) + " > 0",
+		"min:" + strings.Repeat("9", 1<<16),
+	} {
+		done := make(chan struct{})
+
+		go func() {
+			defer close(done)
+
+			_ = New().Validate(struct{ A int }{1}, tag)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Validation hangs for %.20q...", tag)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package vali
+
+import (
+	"maps"
+	"slices"
+)
+
+// CheckerInfo describes a checker or checker maker, i.e. for generating
+// docs or for telling how to fix a misused one. All the fields are optional.
+type CheckerInfo struct {
+	// Description tells what the check does, i.e. "must be >= a and <= b".
+	Description string `json:"description,omitempty"`
+
+	// Kinds lists the (kinds of) values the check applies to,
+	// i.e. "string", "Stringer" or "slice".
+	Kinds []string `json:"kinds,omitempty"`
+
+	// Args tells the syntax of the arguments of a checker maker, i.e. "<a>|<b>".
+	Args string `json:"args,omitempty"`
+
+	// Example is a sample use of the check, as written in a tag.
+	Example string `json:"example,omitempty"`
+
+	// Parent tells that the checker needs the struct enclosing the value
+	// (i.e. to check it against its siblings), so it is passed a [Field].
+	Parent bool `json:"parent,omitempty"`
+}
+
+var (
+	stringKinds  = []string{"string", "Stringer"}
+	numericKinds = []string{"string", "Stringer", "numeric"}
+	bytesKinds   = []string{"string", "Stringer", "[]byte"}
+	ipKinds      = []string{"string", "Stringer", "netip.Addr", "net.IP"}
+	cmpKinds     = []string{"numeric", "len", "time.Time", "math/big", "Comparer"}
+	listKinds    = []string{"slice", "array"}
+)
+
+// builtinInfos describes the builtin checkers and checker makers.
+var builtinInfos = map[string]CheckerInfo{
+	"required":  {Description: "must NOT be IsZero()"},
+	"omitempty": {Description: "skip all checks if zero"},
+	"omitnil":   {Description: "skip all checks if nil"},
+	"trim":      {Description: "trim the spaces, before the checks", Kinds: []string{"settable string"}},
+	"lower":     {Description: "lowercase, before the checks", Kinds: []string{"settable string"}},
+	"upper":     {Description: "uppercase, before the checks", Kinds: []string{"settable string"}},
+	"squish":    {Description: "trim and collapse the spaces, before the checks", Kinds: []string{"settable string"}},
+	"promote":   {Description: "checks apply to promoted fields", Kinds: []string{"embedded struct"}},
+	"msg":       {Description: "custom failure message", Args: "<text>", Example: "msg:'name, please'"},
+	"default": {
+		Description: "set if zero, before the checks", Kinds: []string{"settable field"},
+		Args: "<value>", Example: "default:8080",
+	},
+	"order": {
+		Description: "validate the fields by ascending order", Kinds: []string{"struct field"},
+		Args: "<n>", Example: "order:-1",
+	},
+	"nil_struct": {
+		Description: "nil struct pointer: skip, zero, error", Kinds: []string{"*struct"},
+		Args: "skip|zero|error", Example: "nil_struct:zero",
+	},
+	"regex":           {Description: "must match <rx>", Kinds: stringKinds, Args: "<rx>", Example: "regex:^[a-z]+$"},
+	"eq":              {Description: "must == number", Kinds: cmpKinds, Args: "<number>", Example: "eq:3"},
+	"ne":              {Description: "must != number", Kinds: cmpKinds, Args: "<number>", Example: "ne:0"},
+	"min":             {Description: "must be >= number", Kinds: cmpKinds, Args: "<number>", Example: "min:1"},
+	"max":             {Description: "must be <= number", Kinds: cmpKinds, Args: "<number>", Example: "max:10"},
+	"between":         {Description: "must be >= a and <= b", Kinds: cmpKinds, Args: "<a>|<b>", Example: "between:1|10"},
+	"num_min":         {Description: "numeric string >= n", Kinds: stringKinds, Args: "<n>", Example: "num_min:0.5"},
+	"num_max":         {Description: "numeric string <= n", Kinds: stringKinds, Args: "<n>", Example: "num_max:100"},
+	"num_eq":          {Description: "numeric string == n", Kinds: stringKinds, Args: "<n>", Example: "num_eq:42"},
+	"num_ne":          {Description: "numeric string != n", Kinds: stringKinds, Args: "<n>", Example: "num_ne:0"},
+	"entropy":         {Description: "at least bits of entropy", Kinds: stringKinds, Args: "<bits>", Example: "entropy:60"},
+	"handle":          {Description: "user name on the platform", Kinds: stringKinds, Args: "<platform>", Example: "handle:github"},
+	"git_sha":         {Description: "git commit SHA (full if strict)", Kinds: stringKinds, Args: "strict", Example: "git_sha:strict"},
+	"one_of":          {Description: "must be one of the args", Kinds: stringKinds, Args: "<a>|<b>|...", Example: "one_of:admin|user"},
+	"enum":            {Description: "must be one of the registered enum values", Args: "<name>", Example: "enum:status"},
+	"not_one_of":      {Description: "must be none of the args", Kinds: stringKinds, Args: "<a>|<b>|...", Example: "not_one_of:admin|root"},
+	"subset":          {Description: "all elements in the args", Kinds: listKinds, Args: "<a>|<b>|...", Example: "subset:red|green|blue"},
+	"contains_all":    {Description: "must contain all of the args", Kinds: listKinds, Args: "<a>|<b>|...", Example: "contains_all:read|write"},
+	"required_keys":   {Description: "must have all the args as keys", Kinds: []string{"map"}, Args: "<a>|<b>|...", Example: "required_keys:id|name"},
+	"no_nil_elements": {Description: "no nil pointers or interfaces", Kinds: []string{"slice", "array", "map"}},
+	"csv":             {Description: "CSV record with n fields", Kinds: bytesKinds, Args: "<n>", Example: "csv:3"},
+	"not":             {Description: "must NOT pass check", Args: "<check>", Example: "not:alpha"},
+	"expr": {
+		Description: "expr over sibling fields holds", Kinds: []string{"struct field"},
+		Args: "<expr>", Example: "expr:'End > Start'", Parent: true,
+	},
+	"checksum": {
+		Description: "checksum of the sibling field", Kinds: []string{"struct field"},
+		Args: "crc32|crc32c|adler32:<field>", Example: "checksum:crc32:Payload", Parent: true,
+	},
+	"uuid":      {Description: "32 (dash separated) hexdigits", Kinds: stringKinds},
+	"email":     {Description: "valid email address", Kinds: stringKinds},
+	"url":       {Description: "valid URL with scheme and host", Kinds: stringKinds},
+	"ipv4":      {Description: "valid IPv4 address", Kinds: ipKinds},
+	"ipv6":      {Description: "valid IPv6 address", Kinds: ipKinds},
+	"ip":        {Description: "valid IP address (v4 or v6)", Kinds: ipKinds},
+	"cidr":      {Description: "valid CIDR notation", Kinds: []string{"string", "Stringer", "netip.Prefix", "net.IPNet"}},
+	"mac":       {Description: "valid MAC address", Kinds: stringKinds},
+	"domain":    {Description: "valid domain name", Kinds: stringKinds},
+	"dns_label": {Description: "valid DNS label (RFC 1123)", Kinds: stringKinds},
+	"isbn":      {Description: "valid ISBN-10 or ISBN-13", Kinds: stringKinds},
+	"alpha":     {Description: "letters only", Kinds: stringKinds},
+	"alphanum":  {Description: "letters and numbers only", Kinds: stringKinds},
+	"numeric":   {Description: "numbers only", Kinds: stringKinds},
+	"boolean":   {Description: "valid boolean representation", Kinds: numericKinds},
+	"creditcard": {
+		Description: "valid credit card number (of the given brands)", Kinds: numericKinds,
+		Args: "<brand>|...", Example: "creditcard:visa|mastercard",
+	},
+	"us_state": {
+		Description: "US state code (or territory, military one)", Kinds: stringKinds,
+		Args: "territories|military", Example: "us_state:territories",
+	},
+	"mod11": {
+		Description: "weighted sum of the digits divisible by 11", Kinds: stringKinds,
+		Args: "weights=<w1 w2 ...>", Example: "mod11:weights=3 7 6 1 8 9 4 5 2 1",
+	},
+	"json":        {Description: "valid JSON format", Kinds: numericKinds},
+	"json_object": {Description: "valid JSON object", Kinds: bytesKinds},
+	"json_array":  {Description: "valid JSON array", Kinds: bytesKinds},
+	"json_schema": {
+		Description: "valid as per the registered JSON Schema", Kinds: bytesKinds,
+		Args: "<name>", Example: "json_schema:order",
+	},
+	"geojson":      {Description: "valid GeoJSON geometry", Kinds: bytesKinds},
+	"xml":          {Description: "well-formed XML document", Kinds: bytesKinds},
+	"ascii":        {Description: "ASCII characters only", Kinds: stringKinds},
+	"lowercase":    {Description: "lowercase characters only", Kinds: stringKinds},
+	"uppercase":    {Description: "uppercase characters only", Kinds: stringKinds},
+	"no_html":      {Description: "no HTML tags", Kinds: stringKinds},
+	"html_escaped": {Description: "no unescaped <, > or &", Kinds: stringKinds},
+	"hexadecimal":  {Description: "valid hexadecimal string", Kinds: stringKinds},
+	"base64":       {Description: "valid base64 string", Kinds: stringKinds},
+	"base58":       {Description: "valid base58 string", Kinds: stringKinds},
+	"bech32":       {Description: "valid bech32 or bech32m string", Kinds: stringKinds},
+	"mongoid":      {Description: "valid MongoDB ObjectID", Kinds: stringKinds},
+	"rgb":          {Description: "valid RGB color", Kinds: stringKinds},
+	"rgba":         {Description: "valid RGBA color", Kinds: stringKinds},
+	"luhn":         {Description: "valid luhn string or number", Kinds: numericKinds},
+	"ssn":          {Description: "valid Social Security Number", Kinds: stringKinds},
+	"npi":          {Description: "valid NPI number", Kinds: numericKinds},
+	"nino":         {Description: "valid UK National Insurance number", Kinds: stringKinds},
+	"sin":          {Description: "valid Canadian Social Insurance Number", Kinds: stringKinds},
+	"lei":          {Description: "valid Legal Entity Identifier", Kinds: stringKinds},
+	"duns":         {Description: "valid D-U-N-S number", Kinds: stringKinds},
+	"git_url":      {Description: "valid git remote URL", Kinds: stringKinds},
+	"cve":          {Description: "valid CVE identifier", Kinds: stringKinds},
+	"oid":          {Description: "valid ASN.1 object identifier", Kinds: stringKinds},
+	"calling_code": {Description: "ITU assigned country calling code", Kinds: numericKinds},
+	"mod97":        {Description: "valid ISO 7064 mod 97-10 checksum", Kinds: stringKinds},
+
+	"k8s_name":           {Description: "valid Kubernetes object name", Kinds: stringKinds},
+	"k8s_label_value":    {Description: "valid Kubernetes label value", Kinds: stringKinds},
+	"k8s_qualified_name": {Description: "valid Kubernetes label or annotation key", Kinds: stringKinds},
+	"pem":                {Description: "PEM encoded block(s)", Kinds: bytesKinds},
+	"x509_cert":          {Description: "PEM encoded X.509 certificate(s)", Kinds: bytesKinds},
+	"rsa_public_key":     {Description: "PEM encoded RSA public key", Kinds: bytesKinds},
+}
+
+// DescribeChecker describes the checker (or checker maker) registered under
+// name to the [DefaultValidator]. See [Validator.DescribeChecker] for details.
+func DescribeChecker(name string) (CheckerInfo, bool) {
+	return DefaultValidator.DescribeChecker(name)
+}
+
+// DescribeChecker returns the info the checker (or checker maker) registered
+// under name was registered with, if any, and whether such a checker exists.
+func (v *Validator) DescribeChecker(name string) (info CheckerInfo, ok bool) {
+	return lookup(v, name, func(v *Validator) map[string]CheckerInfo { return v.infos })
+}
+
+// Checkers describes all the checkers registered to the [DefaultValidator].
+// See [Validator.Checkers] for details.
+func Checkers() map[string]CheckerInfo {
+	return DefaultValidator.Checkers()
+}
+
+// Checkers describes all the checkers and checker makers registered to v
+// (including the ones inherited, see [Validator.Child]), by name.
+func (v *Validator) Checkers() (infos map[string]CheckerInfo) {
+	var chain []*Validator
+	for p := v; p != nil; p = p.parent {
+		chain = append(chain, p)
+	}
+
+	infos = map[string]CheckerInfo{}
+
+	for _, p := range slices.Backward(chain) {
+		unlock := p.rlock()
+		maps.Copy(infos, p.infos)
+		unlock()
+	}
+
+	return
+}
+
+// registerInfo records the info name is registered with (the first one of
+// infos, if any). It must be called with v locked.
+func (v *Validator) registerInfo(name string, infos []CheckerInfo) {
+	var info CheckerInfo
+	if len(infos) > 0 {
+		info = infos[0]
+	}
+
+	v.infos[name] = info
+}
+
+// checkerNames returns the names of all the checkers and checker makers of v.
+func (v *Validator) checkerNames() []string {
+	return slices.Sorted(maps.Keys(v.Checkers()))
+}
@@ -0,0 +1,186 @@
+package vali
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// ValidateJSON decodes and validates data against [DefaultValidator].
+// See [Validator.ValidateJSON] for details.
+func ValidateJSON(data []byte, dst any) error {
+	return DefaultValidator.ValidateJSON(data, dst)
+}
+
+// ValidateJSON decodes data into dst (which must be a pointer) and
+// validates it, while keeping track of which keys were actually present
+// in the JSON document. That allows `required` to distinguish an absent
+// key from an explicit zero value: it passes for any present (non null)
+// key, even if zero, and fails for absent ones. All the other checks are
+// skipped for absent keys and run as usual for the present ones.
+func (v *Validator) ValidateJSON(data []byte, dst any) (err error) {
+	if err = json.Unmarshal(data, dst); err != nil {
+		return
+	}
+
+	st := &state{present: map[string]bool{}, sep: v.PathSep}
+	jsonPresence(data, reflect.TypeOf(dst), nil, st)
+	jsonElemsPresence(data, reflect.TypeOf(dst), nil, st)
+
+	ref := reflect.ValueOf(dst)
+
+	return v.observe(ref, st.collected(v.validate(st, ref, "")))
+}
+
+// ValidatePatch decodes and validates data against [DefaultValidator].
+// See [Validator.ValidatePatch] for details.
+func ValidatePatch(data []byte, dst any) error {
+	return DefaultValidator.ValidatePatch(data, dst)
+}
+
+// ValidatePatch decodes the partial JSON document data into dst (which must
+// be a pointer, i.e. to the resource being updated) and validates the fields
+// present in data, the same as [Validator.ValidatePartial] does, i.e. for
+// `PATCH` requests: the absent keys are neither required nor checked.
+func (v *Validator) ValidatePatch(data []byte, dst any) (err error) {
+	if err = json.Unmarshal(data, dst); err != nil {
+		return
+	}
+
+	st := &state{present: map[string]bool{}, sep: v.PathSep, partial: true}
+	jsonPresence(data, reflect.TypeOf(dst), nil, st)
+	jsonElemsPresence(data, reflect.TypeOf(dst), nil, st)
+
+	ref := reflect.ValueOf(dst)
+
+	return v.observe(ref, st.collected(v.validate(st, ref, "")))
+}
+
+// jsonPresence records (in st) the Go paths of the struct fields
+// that have a corresponding (non null) key in data.
+func jsonPresence(data []byte, t reflect.Type, scope []string, st *state) {
+	if t = indirectType(t); t.Kind() != reflect.Struct {
+		return
+	}
+
+	var obj map[string]json.RawMessage
+
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return // Not an object, nothing to record.
+	}
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		path := append(scope[:len(scope):len(scope)], f.Name)
+
+		if name == "-" {
+			continue
+		}
+
+		// Promoted fields are looked up in the same object.
+		if f.Anonymous && name == "" && indirectType(f.Type).Kind() == reflect.Struct {
+			st.markPresent(path)
+			jsonPresence(data, f.Type, path, st)
+
+			continue
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		raw, ok := jsonKey(obj, name)
+		if !ok || bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+			continue
+		}
+
+		st.markPresent(path)
+		jsonPresence(raw, f.Type, path, st)
+		jsonElemsPresence(raw, f.Type, path, st)
+	}
+}
+
+// jsonElemsPresence records the paths of the elements (and their fields)
+// of an array or object holding structs, indexed the same as [Validator.Validate] does.
+func jsonElemsPresence(data []byte, t reflect.Type, scope []string, st *state) {
+	t = indirectType(t)
+
+	switch t.Kind() { //nolint:exhaustive // only collections have elements
+	case reflect.Slice, reflect.Array:
+		var elems []json.RawMessage
+		if err := json.Unmarshal(data, &elems); err != nil {
+			return
+		}
+
+		for i, raw := range elems {
+			jsonElemPresence(raw, t.Elem(), indexScope(scope, i), st)
+		}
+	case reflect.Map:
+		var elems map[string]json.RawMessage
+		if err := json.Unmarshal(data, &elems); err != nil {
+			return
+		}
+
+		for k, raw := range elems {
+			var key any = k
+			if t.Key().Kind() != reflect.String {
+				key = rawKey{k} // I.e. the int keys, rendered unquoted (see indexScope).
+			}
+
+			jsonElemPresence(raw, t.Elem(), indexScope(scope, key), st)
+		}
+	}
+}
+
+// rawKey is a JSON object key rendered as is, rather than quoted.
+type rawKey struct{ key string }
+
+func (k rawKey) String() string {
+	return k.key
+}
+
+func jsonElemPresence(raw []byte, t reflect.Type, path []string, st *state) {
+	if bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+		return
+	}
+
+	st.markPresent(path)
+	jsonPresence(raw, t, path, st)
+}
+
+// jsonField returns the JSON name of the struct field f, the same as
+// encoding/json does (or its Go name, if not encoded), or "" if promoted.
+func jsonField(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+
+	switch {
+	case f.Anonymous && name == "" && indirectType(f.Type).Kind() == reflect.Struct:
+		return ""
+	case name == "" || name == "-":
+		return f.Name
+	default:
+		return name
+	}
+}
+
+// jsonKey looks up key in obj the same way encoding/json does:
+// preferring an exact match, but accepting a case-insensitive one.
+func jsonKey(obj map[string]json.RawMessage, key string) (raw json.RawMessage, ok bool) {
+	if raw, ok = obj[key]; ok {
+		return
+	}
+
+	for k, raw := range obj {
+		if strings.EqualFold(k, key) {
+			return raw, true
+		}
+	}
+
+	return
+}
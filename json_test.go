@@ -0,0 +1,188 @@
+package vali
+
+import (
+	"errors"
+	"testing"
+)
+
+type (
+	patchAddress struct {
+		City string `json:"city" validate:"required"`
+		Zip  string `json:"zip"  validate:"numeric,eq:5"`
+	}
+
+	patchUser struct {
+		Name    string        `json:"name"    validate:"required"`
+		Age     int           `json:"age"     validate:"min:18"`
+		Admin   bool          `json:"admin"   validate:"required"`
+		Email   *string       `json:"email"   validate:"required,email"`
+		Address *patchAddress `json:"address"`
+		Note    string        `json:"-"       validate:"max:3"`
+	}
+)
+
+func TestValidateJSON(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		data   string
+		exp    string
+		expErr error
+	}{
+		{`{"name": "", "admin": false, "email": "bob@example.com"}`, "", nil},
+		{`{"NAME": "Bob", "Admin": true, "email": "bob@example.com", "age": 20}`, "", nil},
+		{`{"admin": false, "email": "bob@example.com"}`, "Name: required check failed: value missing", ErrRequired},
+		{`{"name": "Bob", "email": "bob@example.com"}`, "Admin: required check failed: value missing", ErrRequired},
+		{`{"name": "Bob", "admin": true, "email": null}`, "Email: required check failed: value missing", ErrRequired},
+		{`{"name": "Bob", "admin": true, "email": "bob"}`, `Email: email check failed: "bob" is not a valid email address`, ErrCheckFailed},
+		{`{"name": "Bob", "admin": true, "email": "bob@example.com", "age": 0}`, "Age: min check failed: 0 is less than 18", ErrCheckFailed},
+		{`{"name": "Bob", "admin": true, "email": "bob@example.com", "address": {}}`, "Address.City: required check failed: value missing", ErrRequired},
+		{`{"name": "Bob", "admin": true, "email": "bob@example.com", "address": {"city": "", "zip": "123"}}`, "Address.Zip: eq check failed: len 3 is not equal to 5", ErrCheckFailed},
+		{`{"name": "Bob", "admin": true, "email": "bob@example.com", "address": {"city": "", "zip": "12345"}}`, "", nil},
+	}
+
+	for _, tc := range testCases {
+		var u patchUser
+
+		err := ValidateJSON([]byte(tc.data), &u)
+		if !errors.Is(err, tc.expErr) {
+			t.Fatalf("Expected %v got %v for %s", tc.expErr, err, tc.data)
+		}
+
+		if act := errString(err); act != tc.exp {
+			t.Fatalf("Expected %q got %q", tc.exp, act)
+		}
+	}
+}
+
+func TestValidateJSONElems(t *testing.T) {
+	t.Parallel()
+
+	type book struct {
+		Addresses []patchAddress           `json:"addresses"`
+		ByName    map[string]*patchAddress `json:"by_name"`
+	}
+
+	testCases := []struct {
+		data string
+		exp  string
+	}{
+		{`{"addresses": [{"city": ""}, {"city": "", "zip": "12345"}]}`, ""},
+		{`{"addresses": [{"city": ""}, {"zip": "12345"}]}`, "Addresses[1].City: required check failed: value missing"},
+		{`{"addresses": [{"city": "", "zip": "1"}]}`, "Addresses[0].Zip: eq check failed: len 1 is not equal to 5"},
+		{`{"by_name": {"home": {"city": ""}, "work": null}}`, ""},
+		{`{"by_name": {"home": {}}}`, `ByName["home"].City: required check failed: value missing`},
+	}
+
+	for _, tc := range testCases {
+		var b book
+
+		if act := errString(ValidateJSON([]byte(tc.data), &b)); act != tc.exp {
+			t.Fatalf("Expected %q got %q for %s", tc.exp, act, tc.data)
+		}
+	}
+}
+
+func TestValidateJSONTopLevelElems(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		data string
+		exp  string
+	}{
+		{`[{"city": ""}, {"city": "", "zip": "12345"}]`, ""},
+		{`[{"city": ""}, {"zip": "12345"}]`, "[1].City: required check failed: value missing"},
+		{`[{"city": "", "zip": "1"}]`, "[0].Zip: eq check failed: len 1 is not equal to 5"},
+	}
+
+	for _, tc := range testCases {
+		var addrs []patchAddress
+
+		if act := errString(ValidateJSON([]byte(tc.data), &addrs)); act != tc.exp {
+			t.Fatalf("Expected %q got %q for %s", tc.exp, act, tc.data)
+		}
+	}
+
+	var byName map[string]*patchAddress
+
+	if exp, act := `["home"].City: required check failed: value missing`,
+		errString(ValidateJSON([]byte(`{"home": {}, "work": null}`), &byName)); act != exp {
+		t.Fatalf("Expected %q got %q", exp, act)
+	}
+}
+
+func TestValidateJSONErrors(t *testing.T) {
+	t.Parallel()
+
+	var u patchUser
+
+	if err := ValidateJSON([]byte(`{`), &u); err == nil {
+		t.Fatal("Expected a decoding error")
+	}
+
+	if err := ValidateJSON([]byte(`{}`), u); err == nil {
+		t.Fatal("Expected a decoding error for non pointer")
+	}
+}
+
+func TestValidateJSONEmbedded(t *testing.T) {
+	t.Parallel()
+
+	type withEmbedded struct {
+		patchAddress
+
+		Extra string `json:"extra" validate:"required"`
+	}
+
+	var w withEmbedded
+
+	err := ValidateJSON([]byte(`{"extra": "", "zip": "12345"}`), &w)
+	if act := errString(err); act != "patchAddress.City: required check failed: value missing" {
+		t.Fatalf("Unexpected error %q", act)
+	}
+
+	if err = ValidateJSON([]byte(`{"extra": "", "city": ""}`), &w); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+}
+
+func TestValidatePatch(t *testing.T) {
+	t.Parallel()
+
+	email := "bob@example.com"
+
+	testCases := []struct {
+		data   string
+		exp    string
+		expErr error
+	}{
+		{`{}`, "", nil},
+		{`{"age": 30}`, "", nil},
+		{`{"age": 3}`, "Age: min check failed: 3 is less than 18", ErrCheckFailed},
+		{`{"name": ""}`, "Name: required check failed: value missing", ErrRequired},
+		{`{"email": "bob"}`, `Email: email check failed: "bob" is not a valid email address`, ErrCheckFailed},
+		{`{"address": {"zip": "12345"}}`, "Address.City: required check failed: value missing", ErrRequired},
+		{`{"address": {"zip": "123"}}`, "Address.City: required check failed: value missing", ErrRequired},
+	}
+
+	for _, tc := range testCases {
+		u := patchUser{Name: "Bob", Age: 20, Email: &email}
+
+		err := ValidatePatch([]byte(tc.data), &u)
+		if !errors.Is(err, tc.expErr) {
+			t.Fatalf("Expected %v got %v for %s", tc.expErr, err, tc.data)
+		}
+
+		if act := errString(err); act != tc.exp {
+			t.Fatalf("Expected %q got %q", tc.exp, act)
+		}
+	}
+	v := New()
+	v.PathSep = "/"
+	u := patchUser{Name: "Bob", Age: 20, Email: &email}
+
+	if exp, act := "Address/City: required check failed: value missing",
+		errString(v.ValidatePatch([]byte(`{"address": {"zip": "12345"}}`), &u)); act != exp {
+		t.Fatalf("Expected %q got %q", exp, act)
+	}
+}
@@ -0,0 +1,294 @@
+package vali
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxSchemaRefs caps the nested $ref resolutions, so that cyclic schemas can't loop.
+const maxSchemaRefs = 64
+
+// schemaCheck holds the state of the validation of a JSON document against
+// a schema (see [Validator.RegisterJSONSchema]).
+type schemaCheck struct {
+	*Validator
+
+	depth int // The nested $refs being resolved.
+}
+
+// RegisterJSONSchema registers a JSON Schema to the [DefaultValidator].
+// See [Validator.RegisterJSONSchema] for details.
+func RegisterJSONSchema(name string, s *Schema) {
+	DefaultValidator.RegisterJSONSchema(name, s)
+}
+
+// RegisterJSONSchema registers the JSON Schema s under name, for the
+// `json_schema:<name>` checker to validate the (raw) JSON payloads against,
+// i.e. `json.RawMessage` fields. The subset of JSON Schema modeled by [Schema]
+// is supported, with the $refs resolved by their last segment against the
+// registered schemas, i.e. the ones generated by [Validator.OpenAPISchemas].
+// It panics with [ErrFrozen] if v is frozen (see [Validator.Freeze]).
+func (v *Validator) RegisterJSONSchema(name string, s *Schema) {
+	v.Lock()
+	defer v.Unlock()
+
+	v.mustNotBeFrozen(name)
+	v.schemas[name] = s
+	v.invalidate()
+}
+
+// jsonSchema makes a checker validating the JSON payloads against the
+// schema registered under name, i.e. `json_schema:order`.
+func (v *Validator) jsonSchema(name string) (c Checker, err error) {
+	s, _ := lookup(v, name, func(v *Validator) map[string]*Schema { return v.schemas })
+	if s == nil {
+		return nil, fmt.Errorf("unknown JSON schema %q", name)
+	}
+
+	return func(val reflect.Value) (err error) {
+		var doc any
+		if err = json.Unmarshal(bytesOf(val), &doc); err != nil {
+			return fmt.Errorf("invalid JSON: %w", err)
+		}
+
+		return (&schemaCheck{Validator: v}).check(s, doc, "")
+	}, nil
+}
+
+// jsonObject checks that the value is a JSON object.
+func jsonObject(v reflect.Value) error {
+	return jsonOf(v, '{', "object")
+}
+
+// jsonArray checks that the value is a JSON array.
+func jsonArray(v reflect.Value) error {
+	return jsonOf(v, '[', "array")
+}
+
+func jsonOf(v reflect.Value, start byte, kind string) (err error) {
+	if err = jsoN(v); err != nil {
+		return
+	}
+
+	if b := bytes.TrimSpace(bytesOf(v)); b[0] != start {
+		return fmt.Errorf("JSON is not an %s", kind)
+	}
+
+	return
+}
+
+// check validates doc (as decoded by encoding/json) against s, at path.
+//
+//nolint:gocognit,cyclop // it's a long, but flat, list of keywords
+func (c *schemaCheck) check(s *Schema, doc any, path string) (err error) {
+	// The schema $ref points to applies along with the other keywords of s.
+	if s.Ref != "" {
+		ref, err := c.resolve(s.Ref)
+		if err != nil {
+			return err
+		}
+
+		err = c.check(ref, doc, path)
+		if c.depth--; err != nil {
+			return err
+		}
+	}
+
+	fail := func(format string, args ...any) error {
+		if path == "" {
+			return fmt.Errorf(format, args...)
+		}
+
+		return fmt.Errorf("%s: "+format, append([]any{path}, args...)...)
+	}
+
+	if s.Type != "" && !jsonTypeIs(doc, s.Type) {
+		return fail("expected %s got %s", s.Type, jsonTypeOf(doc))
+	}
+
+	if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(x any) bool { return jsonEqual(x, doc) }) {
+		return fail("%v is not one of %v", doc, s.Enum)
+	}
+
+	if s.Const != nil && !jsonEqual(s.Const, doc) {
+		return fail("%v is not %v", doc, s.Const)
+	}
+
+	if s.Not != nil && c.check(s.Not, doc, path) == nil {
+		return fail("%v must not match the schema", doc)
+	}
+
+	switch x := doc.(type) {
+	case float64:
+		if s.Minimum != nil && x < *s.Minimum {
+			return fail("%v is less than %v", x, *s.Minimum)
+		}
+
+		if s.Maximum != nil && x > *s.Maximum {
+			return fail("%v is more than %v", x, *s.Maximum)
+		}
+	case string:
+		if n := utf8.RuneCountInString(x); s.MinLength != nil && n < *s.MinLength {
+			return fail("len %d is less than %d", n, *s.MinLength)
+		} else if s.MaxLength != nil && n > *s.MaxLength {
+			return fail("len %d is more than %d", n, *s.MaxLength)
+		}
+
+		if s.Pattern != "" {
+			rx, err := compileRegex(s.Pattern)
+			if err != nil {
+				return fail("invalid pattern %q: %w", s.Pattern, err)
+			}
+
+			if !rx.MatchString(x) {
+				return fail("%q does not match %s", x, s.Pattern)
+			}
+		}
+
+		if err = c.checkFormat(s.Format, x); err != nil {
+			return fail("%w", err)
+		}
+	case []any:
+		if s.MinItems != nil && len(x) < *s.MinItems {
+			return fail("len %d is less than %d", len(x), *s.MinItems)
+		}
+
+		if s.MaxItems != nil && len(x) > *s.MaxItems {
+			return fail("len %d is more than %d", len(x), *s.MaxItems)
+		}
+
+		for i, elem := range x {
+			if s.Items != nil {
+				if err = c.check(s.Items, elem, fmt.Sprintf("%s/%d", path, i)); err != nil {
+					return
+				}
+			}
+		}
+	case map[string]any:
+		return c.checkObject(s, x, path, fail)
+	}
+
+	return
+}
+
+// checkObject validates the properties of the object doc against s.
+func (c *schemaCheck) checkObject(s *Schema, doc map[string]any, path string,
+	fail func(string, ...any) error,
+) (err error) {
+	if s.MinProperties != nil && len(doc) < *s.MinProperties {
+		return fail("%d properties is less than %d", len(doc), *s.MinProperties)
+	}
+
+	if s.MaxProperties != nil && len(doc) > *s.MaxProperties {
+		return fail("%d properties is more than %d", len(doc), *s.MaxProperties)
+	}
+
+	for _, name := range s.Required {
+		if _, ok := doc[name]; !ok {
+			return fail("missing property %q", name)
+		}
+	}
+
+	// Sorted, so that the errors are deterministic.
+	for _, name := range slices.Sorted(maps.Keys(doc)) {
+		ps, ok := s.Properties[name]
+		if !ok {
+			ps = s.AdditionalProperties
+		}
+
+		if ps == nil {
+			continue
+		}
+
+		if err = c.check(ps, doc[name], path+"/"+jsonPointerEscaper.Replace(name)); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// checkFormat validates s against format, using the matching builtin checker,
+// if any (see schemaFormats). Unknown formats are ignored, as per the spec.
+func (c *schemaCheck) checkFormat(format, s string) error {
+	if format == "" {
+		return nil
+	}
+
+	for name, f := range schemaFormats {
+		if f == format {
+			if ck := c.checker(name); ck != nil {
+				return ck(reflect.ValueOf(s))
+			}
+		}
+	}
+
+	return nil
+}
+
+// resolve returns the registered schema ref points to (by its last segment).
+func (c *schemaCheck) resolve(ref string) (*Schema, error) {
+	if c.depth++; c.depth > maxSchemaRefs {
+		return nil, fmt.Errorf("too many $refs resolving %q", ref)
+	}
+
+	name := ref[strings.LastIndex(ref, "/")+1:]
+
+	s, _ := lookup(c.Validator, name, func(v *Validator) map[string]*Schema { return v.schemas })
+	if s == nil {
+		return nil, fmt.Errorf("unknown $ref %q", ref)
+	}
+
+	return s, nil
+}
+
+// jsonTypeIs reports whether doc is of the JSON Schema type typ.
+func jsonTypeIs(doc any, typ string) bool {
+	if typ == "integer" {
+		f, ok := doc.(float64)
+		return ok && f == math.Trunc(f)
+	}
+
+	return jsonTypeOf(doc) == typ
+}
+
+// jsonTypeOf returns the JSON Schema type of doc.
+func jsonTypeOf(doc any) string {
+	switch doc.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// jsonEqual reports whether a (from a schema) equals doc, once
+// round tripped through JSON (so that i.e. 1 equals 1.0).
+func jsonEqual(a, doc any) bool {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+
+	var x any
+	if err = json.Unmarshal(data, &x); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(x, doc)
+}
@@ -0,0 +1,93 @@
+package vali
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestJSONRaw(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		val json.RawMessage
+		tag string
+		exp string
+	}{
+		{json.RawMessage(`{"a":1}`), "json", ""},
+		{json.RawMessage(`{"a":`), "json", `json check failed: "{\"a\":" is not valid JSON: unexpected end of JSON input`},
+		{json.RawMessage(` {"a":1}`), "json_object", ""},
+		{json.RawMessage(`[1]`), "json_object", "json_object check failed: JSON is not an object"},
+		{json.RawMessage(`[1]`), "json_array", ""},
+		{json.RawMessage(`"x"`), "json_array", "json_array check failed: JSON is not an array"},
+		{json.RawMessage(`x`), "json_array", `json_array check failed: "x" is not valid JSON: invalid character 'x' looking for beginning of value`},
+	}
+
+	for _, tc := range testCases {
+		if err := Validate(tc.val, tc.tag); errString(err) != tc.exp {
+			t.Fatalf("Expected %q got %v for %s", tc.exp, err, tc.val)
+		}
+	}
+}
+
+func TestRegisterJSONSchema(t *testing.T) {
+	t.Parallel()
+
+	v := New()
+	v.RegisterJSONSchema("item", &Schema{
+		Type:       "object",
+		Properties: map[string]*Schema{"sku": {Type: "string", Pattern: "^[A-Z]+$"}, "qty": {Type: "integer", Minimum: p(1.0)}},
+		Required:   []string{"sku"},
+	})
+	v.RegisterJSONSchema("order", &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"email":  {Type: "string", Format: "email"},
+			"status": {Enum: []any{"new", "paid"}},
+			"items":  {Type: "array", MinItems: p(1), Items: &Schema{Ref: OpenAPIRefPrefix + "item"}},
+		},
+		AdditionalProperties: &Schema{Not: &Schema{Type: "null"}},
+		Required:             []string{"items"},
+	})
+	v.RegisterJSONSchema("loop", &Schema{Ref: "#/$defs/loop"})
+
+	type order struct {
+		Payload json.RawMessage `validate:"json_schema:order"`
+	}
+
+	testCases := []struct {
+		payload string
+		exp     string
+	}{
+		{`{"items":[{"sku":"A","qty":2}],"status":"new","email":"a@b.c","note":"x"}`, ""},
+		{`[]`, "Payload: json_schema check failed: expected object got array"},
+		{`{}`, `Payload: json_schema check failed: missing property "items"`},
+		{`{"items":[]}`, "Payload: json_schema check failed: /items: len 0 is less than 1"},
+		{`{"items":[{"sku":"A"},{"qty":2}]}`, `Payload: json_schema check failed: /items/1: missing property "sku"`},
+		{`{"items":[{"sku":"a"}]}`, `Payload: json_schema check failed: /items/0/sku: "a" does not match ^[A-Z]+$`},
+		{`{"items":[{"sku":"A","qty":1.5}]}`, "Payload: json_schema check failed: /items/0/qty: expected integer got number"},
+		{`{"items":[{"sku":"A","qty":0}]}`, "Payload: json_schema check failed: /items/0/qty: 0 is less than 1"},
+		{`{"items":[{"sku":"A"}],"status":"gone"}`, "Payload: json_schema check failed: /status: gone is not one of [new paid]"},
+		{`{"items":[{"sku":"A"}],"email":"x"}`, `Payload: json_schema check failed: /email: "x" is not a valid email address`},
+		{`{"items":[{"sku":"A"}],"a/b":null}`, "Payload: json_schema check failed: /a~1b: <nil> must not match the schema"},
+		{`{"items":`, "Payload: json_schema check failed: invalid JSON: unexpected end of JSON input"},
+	}
+
+	for _, tc := range testCases {
+		if err := v.Validate(order{json.RawMessage(tc.payload)}); errString(err) != tc.exp {
+			t.Fatalf("Expected %q got %v for %s", tc.exp, err, tc.payload)
+		}
+	}
+
+	if err := v.Validate(json.RawMessage(`1`), "json_schema:loop"); errString(err) != `json_schema check failed: too many $refs resolving "#/$defs/loop"` {
+		t.Fatalf("Expected the cyclic $ref to fail got %v", err)
+	}
+
+	if err := v.CheckTag("json_schema:user"); !errors.Is(err, ErrInvalidChecker) {
+		t.Fatalf("Expected %v got %v", ErrInvalidChecker, err)
+	}
+
+	if err := NewFrom(v).Validate(json.RawMessage(`{"sku":"A"}`), "json_schema:item"); err != nil {
+		t.Fatalf("Expected the schemas to be merged got %v", err)
+	}
+}
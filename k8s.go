@@ -0,0 +1,60 @@
+package vali
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// The Kubernetes object names and labels syntax,
+// the same as k8s.io/apimachinery/pkg/util/validation.
+var (
+	k8sSubdomainRx = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+	k8sNameRx      = regexp.MustCompile(`^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$`)
+)
+
+const (
+	k8sSubdomainMaxLen = 253
+	k8sLabelMaxLen     = 63
+)
+
+// k8sName validates a Kubernetes object name, i.e. of a ConfigMap or of
+// a Namespace: a DNS-1123 subdomain, of at most 253 characters.
+func k8sName(v reflect.Value) (err error) {
+	if s := String(v); len(s) > k8sSubdomainMaxLen || !k8sSubdomainRx.MatchString(s) {
+		return fmt.Errorf("%q is not a valid Kubernetes name", s)
+	}
+
+	return
+}
+
+// k8sLabelValue validates a Kubernetes label value: at most 63 alphanumerics,
+// dashes, underscores or dots, starting and ending with an alphanumeric,
+// or empty.
+func k8sLabelValue(v reflect.Value) (err error) {
+	if s := String(v); len(s) > k8sLabelMaxLen || s != "" && !k8sNameRx.MatchString(s) {
+		return fmt.Errorf("%q is not a valid Kubernetes label value", s)
+	}
+
+	return
+}
+
+// k8sQualifiedName validates a Kubernetes qualified name, i.e. a label or
+// an annotation key: a name (same as a label value, but not empty), optionally
+// prefixed by a DNS-1123 subdomain and a slash, i.e. "app.kubernetes.io/name".
+func k8sQualifiedName(v reflect.Value) (err error) {
+	s := String(v)
+	prefix, name, prefixed := strings.Cut(s, "/")
+
+	if !prefixed {
+		prefix, name = "", s
+	}
+
+	if prefixed && (len(prefix) > k8sSubdomainMaxLen || !k8sSubdomainRx.MatchString(prefix)) ||
+		len(name) > k8sLabelMaxLen || !k8sNameRx.MatchString(name) {
+		return fmt.Errorf("%q is not a valid Kubernetes qualified name", s)
+	}
+
+	return
+}
@@ -0,0 +1,55 @@
+package vali
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestK8s(t *testing.T) {
+	t.Parallel()
+
+	long := strings.Repeat("a", 63)
+	longName := strings.Join([]string{long, long, long, strings.Repeat("a", 61)}, ".")
+
+	tests := []struct { //nolint:govet // ok
+		name    string
+		input   any
+		tag     string
+		wantErr bool
+	}{
+		{"Name", "my-app", "k8s_name", false},
+		{"Name with dots", "my-app.v1.example.com", "k8s_name", false},
+		{"Name max length", longName, "k8s_name", false},
+		{"Name too long", longName + "a", "k8s_name", true},
+		{"Name uppercase", "My-App", "k8s_name", true},
+		{"Name underscore", "my_app", "k8s_name", true},
+		{"Name trailing hyphen", "my-app-", "k8s_name", true},
+		{"Name empty label", "my..app", "k8s_name", true},
+		{"Name trailing dot", "my-app.", "k8s_name", true},
+		{"Label value", "v1.2_beta-3", "k8s_label_value", false},
+		{"Label value uppercase", "Production", "k8s_label_value", false},
+		{"Label value max length", long, "k8s_label_value", false},
+		{"Label value too long", long + "a", "k8s_label_value", true},
+		{"Label value trailing dot", "v1.", "k8s_label_value", true},
+		{"Label value slash", "a/b", "k8s_label_value", true},
+		{"Qualified name", "app", "k8s_qualified_name", false},
+		{"Qualified name prefixed", "app.kubernetes.io/name", "k8s_qualified_name", false},
+		{"Qualified name uppercase", "example.com/MyKey", "k8s_qualified_name", false},
+		{"Qualified name uppercase prefix", "Example.com/key", "k8s_qualified_name", true},
+		{"Qualified name empty prefix", "/name", "k8s_qualified_name", true},
+		{"Qualified name empty name", "example.com/", "k8s_qualified_name", true},
+		{"Qualified name two slashes", "a/b/c", "k8s_qualified_name", true},
+		{"Qualified name too long", long + "a", "k8s_qualified_name", true},
+		{"Qualified name prefix too long", longName + "a/name", "k8s_qualified_name", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := Validate(tt.input, tt.tag); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
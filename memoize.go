@@ -0,0 +1,88 @@
+package vali
+
+import (
+	"container/list"
+	"reflect"
+	"sync"
+	"time"
+)
+
+type (
+	// memoKey identifies a value, along with its type, so that
+	// i.e. int(1) and uint(1) are cached apart.
+	memoKey struct {
+		t reflect.Type
+		x any
+	}
+
+	// memoEntry is the cached outcome of a check.
+	memoEntry struct {
+		key     memoKey
+		err     error
+		expires time.Time
+	}
+)
+
+// Memoize wraps the (expensive, i.e. DNS or database backed) checker ck into
+// one caching its outcomes, by value, for ttl (for ever, if ttl <= 0). Up to
+// maxEntries values are cached (any number of them, if maxEntries <= 0), past
+// which the least recently used ones are evicted. The values that can't be
+// told apart as map keys (i.e. slices, NaNs or unexported fields) are
+// checked every time.
+func Memoize(ck Checker, ttl time.Duration, maxEntries int) Checker {
+	return memoize(ck, ttl, maxEntries, time.Now)
+}
+
+func memoize(ck Checker, ttl time.Duration, maxEntries int, now func() time.Time) Checker {
+	var (
+		entries = map[memoKey]*list.Element{}
+		order   = list.New() // Most recently used first.
+		mx      sync.Mutex
+	)
+
+	return func(val reflect.Value) (err error) {
+		if !val.IsValid() || !val.CanInterface() || !val.Comparable() {
+			return ck(val)
+		}
+
+		key := memoKey{val.Type(), val.Interface()}
+		if key != key { //nolint:gocritic // NaNs are not equal to themselves.
+			return ck(val)
+		}
+
+		mx.Lock()
+		if el, ok := entries[key]; ok {
+			e := el.Value.(*memoEntry) //nolint:forcetypeassert // it only holds entries
+			if ttl <= 0 || now().Before(e.expires) {
+				order.MoveToFront(el)
+				mx.Unlock()
+
+				return e.err
+			}
+
+			order.Remove(el)
+			delete(entries, key)
+		}
+		mx.Unlock()
+
+		// Checked unlocked, so that a slow check does not block the others.
+		err = ck(val)
+
+		mx.Lock()
+		defer mx.Unlock()
+
+		if el, ok := entries[key]; ok { // Checked concurrently, meanwhile.
+			order.Remove(el)
+		}
+
+		entries[key] = order.PushFront(&memoEntry{key: key, err: err, expires: now().Add(ttl)})
+
+		for maxEntries > 0 && order.Len() > maxEntries {
+			el := order.Back()
+			order.Remove(el)
+			delete(entries, el.Value.(*memoEntry).key) //nolint:forcetypeassert // it only holds entries
+		}
+
+		return
+	}
+}
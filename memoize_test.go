@@ -0,0 +1,78 @@
+package vali
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemoize(t *testing.T) {
+	t.Parallel()
+
+	var (
+		calls   atomic.Int32
+		errBusy = errors.New("taken")
+		clock   = time.Now()
+	)
+
+	ck := memoize(func(val reflect.Value) error {
+		calls.Add(1)
+
+		if String(val) == "taken" {
+			return errBusy
+		}
+
+		return nil
+	}, time.Minute, 2, func() time.Time { return clock })
+
+	check := func(val any, expCalls int32, expErr error) {
+		t.Helper()
+
+		if err := ck(reflect.ValueOf(val)); !errors.Is(err, expErr) || calls.Load() != expCalls {
+			t.Fatalf("Expected %v after %d calls got %v after %d", expErr, expCalls, err, calls.Load())
+		}
+	}
+
+	check("free", 1, nil)
+	check("free", 1, nil)
+	check("taken", 2, errBusy)
+	check("taken", 2, errBusy) // The failures are cached too.
+	check("free", 2, nil)
+	check(uint8(1), 3, nil)    // Evicting "taken", the least recently used.
+	check("taken", 4, errBusy) // Evicting "free".
+	check(uint8(1), 4, nil)
+	check(1, 5, nil) // Cached apart from uint8(1).
+	check(uint8(1), 5, nil)
+	check([]string{"a"}, 6, nil) // Not comparable, so never cached.
+	check([]string{"a"}, 7, nil)
+	check(math.NaN(), 8, nil) // Not equal to itself, so never cached.
+	check(math.NaN(), 9, nil)
+	check([1]any{math.NaN()}, 10, nil)
+	check([1]any{math.NaN()}, 11, nil)
+
+	clock = clock.Add(time.Minute)
+
+	check(uint8(1), 12, nil) // Expired.
+
+	type (
+		inner  struct{ Name string }
+		secret struct{ in inner }
+	)
+
+	// The unexported fields can't be interfaced, so they are never cached.
+	for i, val := range []secret{{inner{"a"}}, {inner{"b"}}, {inner{"b"}}} {
+		if ck(reflect.ValueOf(val).Field(0)); calls.Load() != int32(13+i) {
+			t.Fatalf("Expected %d calls got %d", 13+i, calls.Load())
+		}
+	}
+
+	v := New()
+	v.RegisterChecker("available", Memoize(ck, 0, 0))
+
+	if err := v.Validate("taken", "available"); !errors.Is(err, errBusy) {
+		t.Fatalf("Expected %v got %v", errBusy, err)
+	}
+}
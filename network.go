@@ -0,0 +1,122 @@
+package vali
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/mail"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// The checkers in this file touch the network, therefore they are opt-in,
+// i.e. not registered by [New]. Register them explicitly, as needed:
+//
+//	v.RegisterContextChecker("email_mx", vali.EmailMX(nil, 3*time.Second))
+//	v.RegisterContextChecker("url_reachable", vali.URLReachable(nil, 5*time.Second))
+
+// Resolver is the subset of [net.Resolver] used by [EmailMX].
+type Resolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// EmailMX makes a checker (meant to be registered as `email_mx`) that checks
+// the email address syntax, then verifies that its domain has MX records
+// or, failing that, A/AAAA records, using r (or [net.DefaultResolver], if r
+// is nil). The lookups are limited to timeout, in total (unless zero).
+func EmailMX(r Resolver, timeout time.Duration) ContextChecker {
+	if r == nil {
+		r = net.DefaultResolver
+	}
+
+	return func(ctx context.Context, v reflect.Value) (err error) {
+		if err = email(v); err != nil {
+			return
+		}
+
+		addr, _ := mail.ParseAddress(String(v)) // Already validated by email.
+		domain := addr.Address[strings.LastIndex(addr.Address, "@")+1:]
+
+		if timeout > 0 {
+			var cancel context.CancelFunc
+
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		mx, _ := r.LookupMX(ctx, domain) // Failures fall back to the address records, below.
+		if len(mx) > 0 {
+			if mx[0].Host == "." { // RFC 7505 null MX.
+				return fmt.Errorf("%q does not accept email", domain)
+			}
+
+			return nil
+		}
+
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return fmt.Errorf("cannot resolve %s: %w", domain, ctxErr)
+		}
+
+		// No MX records, fall back to the address records (RFC 5321 5.1).
+		hosts, err := r.LookupHost(ctx, domain)
+		if len(hosts) > 0 {
+			return nil
+		}
+
+		if dnsErr := (*net.DNSError)(nil); err == nil || (errors.As(err, &dnsErr) && dnsErr.IsNotFound) {
+			return fmt.Errorf("%q does not accept email", domain)
+		}
+
+		return fmt.Errorf("cannot resolve %s: %w", domain, err)
+	}
+}
+
+// URLReachable makes a checker (meant to be registered as `url_reachable`)
+// that checks the URL syntax, then sends it a HEAD request using client (or
+// [http.DefaultClient], if nil), which must succeed (redirects are followed,
+// as per the client policy) within timeout (unless zero). Servers responding
+// with 405 (Method Not Allowed) are considered reachable, as they don't
+// support HEAD requests, but are up. It touches the network, so use it
+// sparingly, i.e. when registering webhook endpoints.
+func URLReachable(client *http.Client, timeout time.Duration) ContextChecker {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return func(ctx context.Context, v reflect.Value) (err error) {
+		if err = urL(v); err != nil {
+			return
+		}
+
+		if timeout > 0 {
+			var cancel context.CancelFunc
+
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		s := String(v)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodHead, s, http.NoBody)
+		if err != nil {
+			return
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("%q is not reachable: %w", s, err)
+		}
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= http.StatusBadRequest && resp.StatusCode != http.StatusMethodNotAllowed {
+			return fmt.Errorf("%q is not reachable: %s", s, resp.Status)
+		}
+
+		return
+	}
+}
@@ -0,0 +1,156 @@
+package vali
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type fakeResolver map[string][]string
+
+func (r fakeResolver) LookupMX(ctx context.Context, name string) (mx []*net.MX, err error) {
+	if name == "slow.test" {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	for _, host := range r["mx:"+name] {
+		mx = append(mx, &net.MX{Host: host})
+	}
+
+	if len(mx) == 0 {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+
+	return
+}
+
+func (r fakeResolver) LookupHost(_ context.Context, host string) ([]string, error) {
+	if host == "broken.test" {
+		return nil, &net.DNSError{Err: "server misbehaving", Name: host, IsTemporary: true}
+	}
+
+	if hosts := r["a:"+host]; len(hosts) > 0 {
+		return hosts, nil
+	}
+
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func TestEmailMX(t *testing.T) {
+	t.Parallel()
+
+	r := fakeResolver{
+		"mx:example.com":  {"mx1.example.com."},
+		"a:example.org":   {"192.0.2.1"},
+		"mx:nomail.test":  {"."},
+		"a:nomail.test":   {"192.0.2.2"},
+		"mx:example.test": nil,
+	}
+
+	v := New()
+	v.RegisterContextChecker("email_mx", EmailMX(r, 50*time.Millisecond))
+
+	testCases := []struct {
+		val string
+		exp string
+	}{
+		{"bob@example.com", ""},
+		{"Bob <bob@example.org>", ""},
+		{"", ""},
+		{"bob", `email_mx check failed: "bob" is not a valid email address`},
+		{"bob@nomail.test", `email_mx check failed: "nomail.test" does not accept email`},
+		{"bob@example.test", `email_mx check failed: "example.test" does not accept email`},
+		{"bob@broken.test", "email_mx check failed: cannot resolve broken.test: lookup broken.test: server misbehaving"},
+		{"bob@slow.test", "email_mx check failed: cannot resolve slow.test: context deadline exceeded"},
+	}
+
+	for _, tc := range testCases {
+		if act := errString(v.ValidateContext(context.Background(), tc.val, "email_mx")); act != tc.exp {
+			t.Fatalf("Expected %q got %q for %q", tc.exp, act, tc.val)
+		}
+
+		if act := errString(v.Validate(tc.val, "email_mx")); act != tc.exp {
+			t.Fatalf("Expected %q got %q for %q", tc.exp, act, tc.val)
+		}
+	}
+}
+
+func TestValidateContext(t *testing.T) {
+	t.Parallel()
+
+	type key struct{}
+
+	v := New()
+	v.RegisterContextChecker("ctx", func(ctx context.Context, _ reflect.Value) error {
+		if ctx.Value(key{}) == nil {
+			return errors.New("no context")
+		}
+
+		return nil
+	})
+
+	ctx := context.WithValue(context.Background(), key{}, true)
+
+	if err := v.ValidateContext(ctx, "x", "ctx"); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if exp, act := "ctx check failed: no context", errString(v.Validate("x", "ctx")); act != exp {
+		t.Fatalf("Expected %q got %q", exp, act)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	if err := v.ValidateContext(ctx, "x", "ctx"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected %v got %v", context.Canceled, err)
+	}
+}
+
+func TestURLReachable(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+		case "/moved":
+			http.Redirect(w, r, "/ok", http.StatusFound)
+		case "/get-only":
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		case "/slow":
+			<-r.Context().Done()
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	v := New()
+	v.RegisterContextChecker("url_reachable", URLReachable(srv.Client(), 50*time.Millisecond))
+
+	testCases := []struct {
+		val string
+		exp string
+	}{
+		{srv.URL + "/ok", ""},
+		{srv.URL + "/moved", ""},
+		{srv.URL + "/get-only", ""},
+		{"", ""},
+		{"nope", `url_reachable check failed: "nope" is not a valid URL (missing scheme or host)`},
+		{srv.URL + "/gone", `url_reachable check failed: "` + srv.URL + `/gone" is not reachable: 404 Not Found`},
+		{srv.URL + "/slow", `url_reachable check failed: "` + srv.URL + `/slow" is not reachable: Head "` +
+			srv.URL + `/slow": context deadline exceeded`},
+	}
+
+	for _, tc := range testCases {
+		if act := errString(v.ValidateContext(context.Background(), tc.val, "url_reachable")); act != tc.exp {
+			t.Fatalf("Expected %q got %q for %q", tc.exp, act, tc.val)
+		}
+	}
+}
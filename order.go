@@ -0,0 +1,84 @@
+package vali
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// order is the checker maker of the order modifier, i.e. `order:-1`, which
+// only needs to be parsed, it is applied by the validator itself (see fieldOrder).
+func order(args string) (c Checker, err error) {
+	if _, err = ParseIntArg(args); err != nil {
+		return
+	}
+
+	return noop, nil
+}
+
+// fieldOrder returns the indexes of the fields of t, sorted by their `order:<n>`
+// modifiers (0 if missing), the declaration order breaking the ties. It returns
+// nil when none of the fields has one, for them to be validated in order.
+func (v *Validator) fieldOrder(t reflect.Type, rules map[string]string) (idx []int) {
+	prio := make([]int, t.NumField())
+	sorted := false
+
+	for i := range t.NumField() {
+		tag := v.fieldTag(t.Field(i), rules)
+		if !strings.Contains(tag, "order"+v.CheckArgSep) {
+			continue
+		}
+
+		_, chkNames, err := v.parse(tag)
+		if err != nil {
+			continue // Reported by the validation of tag itself.
+		}
+
+		for _, name := range chkNames {
+			if name, args, _ := strings.Cut(name, v.CheckArgSep); name == "order" {
+				prio[i], _ = ParseIntArg(args)
+				sorted = sorted || prio[i] != 0
+			}
+		}
+	}
+
+	if !sorted {
+		return
+	}
+
+	idx = make([]int, t.NumField())
+	for i := range idx {
+		idx[i] = i
+	}
+
+	slices.SortStableFunc(idx, func(a, b int) int { return cmp.Compare(prio[a], prio[b]) })
+
+	return
+}
+
+// checkOrder returns the checks (and their names) sorted by [Validator.CheckOrder],
+// the tag order breaking the ties. They are returned as they are, if it's not set.
+func (v *Validator) checkOrder(checks []Checker, names []string) ([]Checker, []string) {
+	if v.CheckOrder == nil || len(checks) < 2 {
+		return checks, names
+	}
+
+	idx := make([]int, len(checks))
+	prio := make([]int, len(checks))
+
+	for i, name := range names {
+		idx[i] = i
+		name, _, _ = strings.Cut(name, v.CheckArgSep)
+		prio[i] = v.CheckOrder(name)
+	}
+
+	slices.SortStableFunc(idx, func(a, b int) int { return cmp.Compare(prio[a], prio[b]) })
+
+	sortedChecks, sortedNames := make([]Checker, len(idx)), make([]string, len(idx))
+	for i, j := range idx {
+		sortedChecks[i], sortedNames[i] = checks[j], names[j]
+	}
+
+	return sortedChecks, sortedNames
+}
@@ -0,0 +1,93 @@
+package vali
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// pemBlocks decodes the PEM blocks of the value, failing if there are none
+// or if anything but whitespace follows them.
+func pemBlocks(v reflect.Value) (blocks []*pem.Block, err error) {
+	rest := bytesOf(v)
+
+	for {
+		var b *pem.Block
+		if b, rest = pem.Decode(rest); b == nil {
+			break
+		}
+
+		blocks = append(blocks, b)
+	}
+
+	if len(blocks) == 0 || len(bytes.TrimSpace(rest)) > 0 {
+		return nil, errors.New("not PEM encoded")
+	}
+
+	return
+}
+
+// pemCheck validates that the value holds (only) PEM blocks.
+func pemCheck(v reflect.Value) (err error) {
+	_, err = pemBlocks(v)
+
+	return
+}
+
+// x509Cert validates that the value holds (only) PEM encoded X.509
+// certificates, i.e. a certificate or a chain of them.
+func x509Cert(v reflect.Value) (err error) {
+	blocks, err := pemBlocks(v)
+	if err != nil {
+		return
+	}
+
+	for i, b := range blocks {
+		if b.Type != "CERTIFICATE" {
+			return fmt.Errorf("PEM block %d is a %s, not a CERTIFICATE", i, b.Type)
+		}
+
+		if _, err = x509.ParseCertificate(b.Bytes); err != nil {
+			return fmt.Errorf("PEM block %d is not a valid certificate", i)
+		}
+	}
+
+	return
+}
+
+// rsaPublicKey validates that the value holds a PEM encoded RSA public key,
+// either as a PUBLIC KEY (PKIX) or as an RSA PUBLIC KEY (PKCS #1) block.
+func rsaPublicKey(v reflect.Value) (err error) {
+	blocks, err := pemBlocks(v)
+	if err != nil {
+		return
+	}
+
+	if len(blocks) != 1 {
+		return fmt.Errorf("%d PEM blocks, expecting one", len(blocks))
+	}
+
+	switch b := blocks[0]; b.Type {
+	case "RSA PUBLIC KEY":
+		if _, err = x509.ParsePKCS1PublicKey(b.Bytes); err != nil {
+			return errors.New("not a valid RSA public key")
+		}
+	case "PUBLIC KEY":
+		key, err := x509.ParsePKIXPublicKey(b.Bytes)
+		if err != nil {
+			return errors.New("not a valid public key")
+		}
+
+		if _, ok := key.(*rsa.PublicKey); !ok {
+			return fmt.Errorf("a %T, not an RSA public key", key)
+		}
+	default:
+		return fmt.Errorf("PEM block is a %s, not a PUBLIC KEY", b.Type)
+	}
+
+	return
+}
@@ -0,0 +1,82 @@
+package vali
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPEM(t *testing.T) {
+	t.Parallel()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{SerialNumber: big.NewInt(1), NotBefore: time.Now(), NotAfter: time.Now().Add(time.Hour)}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &ecKey.PublicKey, ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkix, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ecPKIX, err := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encode := func(typ string, b []byte) string {
+		return string(pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: b}))
+	}
+
+	cert := encode("CERTIFICATE", der)
+	pubKey := encode("PUBLIC KEY", pkix)
+
+	testCases := []struct {
+		val any
+		tag string
+		exp string
+	}{
+		{cert, "pem", ""},
+		{[]byte(pubKey), "pem", ""},
+		{"  \n" + cert + cert + "\n", "pem", ""},
+		{"not a certificate", "pem", "pem check failed: not PEM encoded"},
+		{cert + "trailing", "pem", "pem check failed: not PEM encoded"},
+		{cert, "x509_cert", ""},
+		{cert + cert, "x509_cert", ""},
+		{cert + pubKey, "x509_cert", "x509_cert check failed: PEM block 1 is a PUBLIC KEY, not a CERTIFICATE"},
+		{encode("CERTIFICATE", []byte("junk")), "x509_cert", "x509_cert check failed: PEM block 0 is not a valid certificate"},
+		{strings.TrimSuffix(cert, "-----END CERTIFICATE-----\n"), "x509_cert", "x509_cert check failed: not PEM encoded"},
+		{pubKey, "rsa_public_key", ""},
+		{encode("RSA PUBLIC KEY", x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey)), "rsa_public_key", ""},
+		{encode("PUBLIC KEY", ecPKIX), "rsa_public_key", "rsa_public_key check failed: a *ecdsa.PublicKey, not an RSA public key"},
+		{cert, "rsa_public_key", "rsa_public_key check failed: PEM block is a CERTIFICATE, not a PUBLIC KEY"},
+		{pubKey + pubKey, "rsa_public_key", "rsa_public_key check failed: 2 PEM blocks, expecting one"},
+		{encode("RSA PUBLIC KEY", []byte("junk")), "rsa_public_key", "rsa_public_key check failed: not a valid RSA public key"},
+		{encode("PUBLIC KEY", []byte("junk")), "rsa_public_key", "rsa_public_key check failed: not a valid public key"},
+	}
+
+	for _, tc := range testCases {
+		if act := errString(Validate(tc.val, tc.tag)); act != tc.exp {
+			t.Fatalf("Expected %q got %q for %s", tc.exp, act, tc.tag)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package vali
+
+import (
+	"container/list"
+	"regexp"
+	"sync"
+)
+
+// DefaultRegexCacheSize is the default number of compiled regexps kept by
+// the cache shared by all the validators (see [SetRegexCacheSize]).
+const DefaultRegexCacheSize = 1024
+
+// RegexStats holds the counters of the shared regexp cache.
+type RegexStats struct {
+	Hits, Misses, Evictions uint64
+
+	// Len is the number of regexps currently cached.
+	Len int
+}
+
+// regexCache is a (bounded) LRU cache of compiled regexps, keyed by pattern.
+type regexCache struct {
+	entries map[string]*list.Element
+	order   *list.List // Most recently used first, holding *regexp.Regexp.
+	size    int
+	stats   RegexStats
+
+	sync.Mutex //nolint:embeddedstructfieldcheck // ok
+}
+
+var regexps = &regexCache{entries: map[string]*list.Element{}, order: list.New(), size: DefaultRegexCacheSize}
+
+// SetRegexCacheSize sets the number of compiled regexps kept by the cache
+// shared by all the validators (used by regex, one_of, json_schema, etc.),
+// evicting the least recently used ones past it. Zero disables the cache.
+func SetRegexCacheSize(n int) {
+	regexps.Lock()
+	defer regexps.Unlock()
+
+	regexps.size = max(n, 0)
+	regexps.evict()
+}
+
+// RegexCacheStats returns the counters of the shared regexp cache,
+// i.e. to export them as metrics.
+func RegexCacheStats() RegexStats {
+	regexps.Lock()
+	defer regexps.Unlock()
+
+	stats := regexps.stats
+	stats.Len = regexps.order.Len()
+
+	return stats
+}
+
+// compileRegex compiles pattern, going through the shared cache.
+func compileRegex(pattern string) (rx *regexp.Regexp, err error) {
+	regexps.Lock()
+	if el, ok := regexps.entries[pattern]; ok {
+		regexps.order.MoveToFront(el)
+		regexps.stats.Hits++
+		regexps.Unlock()
+
+		return el.Value.(*regexp.Regexp), nil //nolint:forcetypeassert // it only holds regexps
+	}
+
+	regexps.stats.Misses++
+	regexps.Unlock()
+
+	// Compiled unlocked, so that a slow pattern does not block the others.
+	if rx, err = regexp.Compile(pattern); err != nil {
+		return
+	}
+
+	regexps.Lock()
+	defer regexps.Unlock()
+
+	if _, ok := regexps.entries[pattern]; !ok && regexps.size > 0 {
+		regexps.entries[pattern] = regexps.order.PushFront(rx)
+		regexps.evict()
+	}
+
+	return
+}
+
+// evict drops the least recently used regexps past the cache size.
+// It must be called with the cache locked.
+func (c *regexCache) evict() {
+	for c.order.Len() > c.size {
+		el := c.order.Back()
+		c.order.Remove(el)
+		delete(c.entries, el.Value.(*regexp.Regexp).String()) //nolint:forcetypeassert // it only holds regexps
+		c.stats.Evictions++
+	}
+}
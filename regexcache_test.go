@@ -0,0 +1,47 @@
+package vali
+
+import "testing"
+
+//nolint:paralleltest // it resizes the shared cache
+func TestRegexCache(t *testing.T) {
+	t.Cleanup(func() { SetRegexCacheSize(DefaultRegexCacheSize) })
+
+	before := RegexCacheStats()
+
+	for range 3 {
+		if err := Validate("abc", "regex:^cache_[a-c]+$"); err == nil {
+			t.Fatal("Expected the regex check to fail")
+		}
+	}
+
+	if _, err := compileRegex("[cache"); err == nil {
+		t.Fatal("Expected an invalid pattern to fail")
+	}
+
+	stats := RegexCacheStats()
+	if hits, misses := stats.Hits-before.Hits, stats.Misses-before.Misses; hits != 0 || misses != 2 {
+		t.Fatalf("Expected 0 hits and 2 misses (the checker is cached per tag) got %d, %d", hits, misses)
+	}
+
+	for range 2 {
+		if _, err := New().oneOf("cache|test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if stats = RegexCacheStats(); stats.Hits-before.Hits != 1 {
+		t.Fatalf("Expected a hit across validators got %+v", stats)
+	}
+
+	SetRegexCacheSize(1)
+
+	if stats = RegexCacheStats(); stats.Len != 1 || stats.Evictions == before.Evictions {
+		t.Fatalf("Expected the cache to be shrunk got %+v", stats)
+	}
+
+	SetRegexCacheSize(0)
+
+	if _, err := compileRegex("^cache$"); err != nil || RegexCacheStats().Len != 0 {
+		t.Fatalf("Expected the cache to be disabled got %+v, %v", RegexCacheStats(), err)
+	}
+}
@@ -0,0 +1,62 @@
+package vali
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+type (
+	// Outcomes lists the outcomes of the checks applied by a validation,
+	// in the order they ran (see [Validator.Report]).
+	Outcomes []CheckOutcome
+
+	// CheckOutcome is the outcome of a check applied to a field.
+	CheckOutcome struct {
+		// Field is the path to the field (see [FieldError.Field]),
+		// it is empty at the top level.
+		Field string `json:"field"`
+
+		// Check is the name of the check, without its arguments (or
+		// the whole tag, for the fields omitted via omitempty/omitnil).
+		Check string `json:"check"`
+
+		// Outcome is one of "passed", "failed", "omitted", "skipped zero"
+		// or "skipped absent" (see [Validator.ValidateJSON]).
+		Outcome string `json:"outcome"`
+
+		// Error is the message of the failure, if any.
+		Error string `json:"error,omitempty"`
+
+		// Duration is how long the check took, if it ran.
+		Duration time.Duration `json:"duration,omitempty"`
+	}
+)
+
+// Report validates val (and tags) against the [DefaultValidator], reporting every check.
+// See [Validator.Report] for details.
+func Report(val any, tags ...string) (Outcomes, error) {
+	return DefaultValidator.Report(val, tags...)
+}
+
+// Report works like [Validator.Validate], except that it goes on past the
+// failures (regardless of [Validator.MaxErrors]) and lists the outcome of
+// every check applied (or skipped), i.e. for an audit trail of the rules
+// that were actually evaluated for a record.
+func (v *Validator) Report(val any, tags ...string) (r Outcomes, err error) {
+	ref := reflect.ValueOf(val)
+	st := &state{maxErrors: -1, report: &r}
+
+	return r, v.observe(ref, st.collected(v.validate(st, ref, strings.Join(tags, v.CheckSep))))
+}
+
+// Failed returns the failed checks of r.
+func (r Outcomes) Failed() (failed Outcomes) {
+	for _, o := range r {
+		if o.Outcome == "failed" {
+			failed = append(failed, o)
+		}
+	}
+
+	return
+}
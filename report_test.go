@@ -0,0 +1,55 @@
+package vali
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestReport(t *testing.T) {
+	t.Parallel()
+
+	type record struct {
+		ID    string `validate:"required,uuid"`
+		Email string `validate:"email"`
+		Age   int    `validate:"min:18,max:150"`
+		Note  string `validate:"omitempty,max:3"`
+	}
+
+	r, err := Report(record{ID: "x", Age: 200})
+	if !errors.Is(err, ErrCheckFailed) {
+		t.Fatalf("Expected %v got %v", ErrCheckFailed, err)
+	}
+
+	exp := Outcomes{
+		{Field: "ID", Check: "required", Outcome: "passed"},
+		{Field: "ID", Check: "uuid", Outcome: "failed", Error: `"x" does not match ` + "(?i)^[0-9a-f]{8}-?[0-9a-f]{4}-?[0-9a-f]{4}-?[0-9a-f]{4}-?[0-9a-f]{12}$"},
+		{Field: "Email", Check: "email", Outcome: "skipped zero"},
+		{Field: "Age", Check: "min", Outcome: "passed"},
+		{Field: "Age", Check: "max", Outcome: "failed", Error: "200 is more than 150"},
+		{Field: "Note", Check: "omitempty,max:3", Outcome: "omitted"},
+	}
+
+	for i := range r {
+		r[i].Duration = 0
+	}
+
+	if !slices.Equal(r, exp) {
+		t.Fatalf("Expected\n%+v\ngot\n%+v", exp, r)
+	}
+
+	if failed := r.Failed(); len(failed) != 2 || failed[1].Field != "Age" {
+		t.Fatalf("Unexpected failures %+v", failed)
+	}
+
+	v := New()
+	v.RootTypeName = true
+
+	if r, err = v.Report("abc", "min:2"); err != nil || len(r) != 1 || r[0].Field != "" || r[0].Outcome != "passed" {
+		t.Fatalf("Unexpected report %+v, %v", r, err)
+	}
+
+	if r, _ = v.Report(record{ID: "x"}); r[0].Field != "record.ID" {
+		t.Fatalf("Expected the root type name got %+v", r[0])
+	}
+}
@@ -0,0 +1,140 @@
+package vali
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Rules holds validation rules defined outside of the struct tags,
+// as type name -> field name -> tag, i.e.:
+//
+//	{"net/mail.Address": {"Address": "required,email"}}
+//
+// Type names can be either fully qualified (package path + "." + type name)
+// or in the short form returned by [reflect.Type.String] ("mail.Address").
+// The rules of a generic type ("vali.Page") apply to all its instantiations,
+// along with the ones of each instantiation, if any, where the type arguments
+// are always fully qualified ("vali.Page[github.com/alexaandru/vali.User]").
+type Rules map[string]map[string]string
+
+// LoadRules attaches rules to the [DefaultValidator].
+// See [Validator.LoadRules] for details.
+func LoadRules(r Rules) {
+	DefaultValidator.LoadRules(r)
+}
+
+// LoadRules attaches validation rules to types, without the need for
+// struct tags, i.e. for third party structs that cannot be annotated.
+// Rules take precedence over struct tags and they are merged with
+// the ones previously loaded, if any. It panics if v is frozen.
+func (v *Validator) LoadRules(r Rules) {
+	v.Lock()
+	defer v.Unlock()
+
+	v.mustNotBeFrozen("rules")
+	v.mergeRules(r)
+}
+
+// mergeRules merges r into the rules of v. It must be called with v locked.
+func (v *Validator) mergeRules(r Rules) {
+	if v.rules == nil {
+		v.rules = Rules{}
+	}
+
+	// Copy on write, so that readers can use the maps outside the lock.
+	for typ, fields := range r {
+		merged := maps.Clone(v.rules[typ])
+		if merged == nil {
+			merged = map[string]string{}
+		}
+
+		maps.Copy(merged, fields)
+		v.rules[typ] = merged
+	}
+}
+
+// LoadRulesJSON attaches rules from a JSON document to the [DefaultValidator].
+// See [Validator.LoadRulesJSON] for details.
+func LoadRulesJSON(data []byte) error {
+	return DefaultValidator.LoadRulesJSON(data)
+}
+
+// LoadRulesJSON loads [Rules] from a JSON document. For other formats
+// (i.e. YAML), unmarshal them into a [Rules] and use [Validator.LoadRules].
+func (v *Validator) LoadRulesJSON(data []byte) (err error) {
+	var r Rules
+
+	if err = json.Unmarshal(data, &r); err != nil {
+		return
+	}
+
+	v.LoadRules(r)
+
+	return
+}
+
+// typeRules returns the rules attached to t, if any.
+func (v *Validator) typeRules(t reflect.Type) (r map[string]string, err error) {
+	if t.Name() == "" {
+		return
+	}
+
+	// The long form of the type name wins over the short one, the rules of
+	// a generic type instantiation win over the ones of the generic type
+	// ("vali.Page") and the rules of v win over the ones of its parents
+	// (see [Validator.Child]).
+	long, short := t.PkgPath()+"."+t.Name(), t.String()
+	genericLong, _, _ := strings.Cut(long, "[")
+	genericShort, _, generic := strings.Cut(short, "[")
+
+	var layers []map[string]string
+
+	for p := v; p != nil; p = p.parent {
+		unlock := p.rlock()
+		layers = append(layers, p.rules[long], p.rules[short])
+
+		if generic {
+			layers = append(layers, p.rules[genericLong], p.rules[genericShort])
+		}
+		unlock()
+	}
+
+	for _, layer := range slices.Backward(layers) {
+		switch {
+		case layer == nil:
+		case r == nil:
+			r = layer
+		default:
+			r = maps.Clone(r)
+			maps.Copy(r, layer)
+		}
+	}
+
+	for field := range r {
+		if f, ok := t.FieldByName(field); !ok || len(f.Index) > 1 {
+			return nil, fmt.Errorf("%w: %s has no field %s", ErrInvalidChecker, t, field)
+		}
+	}
+
+	return
+}
+
+// fieldTag returns the (trimmed) validation tag of f, taking
+// the rules into account.
+func (v *Validator) fieldTag(f reflect.StructField, r map[string]string) string {
+	if tag, ok := r[f.Name]; ok {
+		return strings.TrimSpace(tag)
+	}
+
+	for _, name := range v.tags {
+		if tag, ok := f.Tag.Lookup(name); ok {
+			return strings.TrimSpace(tag)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,153 @@
+package vali
+
+import (
+	"errors"
+	"net/mail"
+	"testing"
+)
+
+type ruled struct {
+	Name  string
+	Email string `validate:"email"`
+	Inner struct {
+		Code string
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	t.Parallel()
+
+	v := New()
+	v.LoadRules(Rules{"net/mail.Address": {"Name": "required"}})
+	v.LoadRules(Rules{"mail.Address": {"Address": "required,email"}})
+
+	testCases := []struct {
+		val any
+		exp string
+	}{
+		{mail.Address{Name: "Bob", Address: "bob@example.com"}, ""},
+		{mail.Address{Address: "bob@example.com"}, "Name: required check failed: value missing"},
+		{&mail.Address{Name: "Bob", Address: "bob"}, `Address: email check failed: "bob" is not a valid email address`},
+		{struct{ A mail.Address }{}, "A.Name: required check failed: value missing"},
+	}
+
+	for _, tc := range testCases {
+		err := v.Validate(tc.val)
+		if act := errString(err); act != tc.exp {
+			t.Fatalf("Expected %q got %q", tc.exp, act)
+		}
+	}
+}
+
+type (
+	genericUser struct {
+		Name string `validate:"required"`
+	}
+
+	genericOrder struct {
+		ID string `validate:"alpha"`
+	}
+
+	genericPage[T any] struct {
+		Items []T
+		Next  string
+		Total int
+	}
+)
+
+func TestLoadRulesGeneric(t *testing.T) {
+	t.Parallel()
+
+	v := New()
+	v.LoadRules(Rules{
+		"vali.genericPage": {"Items": "max:2"},
+		"github.com/alexaandru/vali.genericPage[github.com/alexaandru/vali.genericUser]": {"Total": "min:1"},
+		"vali.genericPage[github.com/alexaandru/vali.genericOrder]":                      {"Next": "required", "Items": "max:3"},
+	})
+
+	testCases := []struct {
+		val any
+		exp string
+	}{
+		{genericPage[genericUser]{Items: []genericUser{{"a"}}, Total: 1}, ""},
+		{genericPage[genericUser]{Items: []genericUser{{"a"}, {}}, Total: 1}, "Items[1].Name: required check failed: value missing"},
+		{genericPage[genericUser]{Items: []genericUser{{"a"}, {"b"}, {"c"}}, Total: 1}, "Items: max check failed: len 3 is more than 2"},
+		{genericPage[genericUser]{Items: []genericUser{{"a"}}}, "Total: min check failed: 0 is less than 1"},
+		{genericPage[genericOrder]{Items: []genericOrder{{"x1"}}, Next: "n"}, `Items[0].ID: alpha check failed: "x1" does not match (?i)^[a-z]*$`},
+		{genericPage[genericOrder]{Items: []genericOrder{{}, {}, {}}, Next: "n"}, ""},
+		{genericPage[genericOrder]{}, "Next: required check failed: value missing"},
+		{genericPage[int]{Items: []int{1, 2, 3}}, "Items: max check failed: len 3 is more than 2"},
+	}
+
+	for _, tc := range testCases {
+		if act := errString(v.Validate(tc.val)); act != tc.exp {
+			t.Fatalf("Expected %q got %q for %T", tc.exp, act, tc.val)
+		}
+	}
+}
+
+func TestLoadRulesJSON(t *testing.T) {
+	t.Parallel()
+
+	v := New()
+
+	if err := v.LoadRulesJSON([]byte(`{"vali.ruled": {"Name": "required", "Email": ""}}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Rules take precedence over tags.
+	if err := v.Validate(ruled{Name: "x", Email: "not-an-email"}); err != nil {
+		t.Fatalf("Expected no error got %v", err)
+	}
+
+	if err := v.Validate(ruled{}); errString(err) != "Name: required check failed: value missing" {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if err := v.LoadRulesJSON([]byte(`{`)); err == nil {
+		t.Fatal("Expected error")
+	}
+}
+
+func TestLoadRulesUnknownField(t *testing.T) {
+	t.Parallel()
+
+	v := New()
+	v.LoadRules(Rules{"vali.ruled": {"Bogus": "required", "Code": "required"}})
+
+	if err := v.Validate(ruled{}); !errors.Is(err, ErrInvalidChecker) {
+		t.Fatalf("Expected %v got %v", ErrInvalidChecker, err)
+	}
+
+	if _, err := v.JSONSchema(ruled{}); !errors.Is(err, ErrInvalidChecker) {
+		t.Fatalf("Expected %v got %v", ErrInvalidChecker, err)
+	}
+}
+
+func TestLoadRulesDefaultValidator(t *testing.T) {
+	t.Parallel()
+
+	type loaded struct{ Foo string }
+
+	LoadRules(Rules{"vali.loaded": {"Foo": "required"}})
+
+	if err := Validate(loaded{}); !errors.Is(err, ErrRequired) {
+		t.Fatalf("Expected %v got %v", ErrRequired, err)
+	}
+
+	if err := LoadRulesJSON([]byte(`{"vali.loaded": {"Foo": "min:2"}}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Validate(loaded{Foo: "x"}); !errors.Is(err, ErrCheckFailed) {
+		t.Fatalf("Expected %v got %v", ErrCheckFailed, err)
+	}
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return err.Error()
+}
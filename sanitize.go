@@ -0,0 +1,129 @@
+package vali
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// Sanitizer modifies the (settable) value it gets, i.e. to normalize it,
+// before the checks run. It is only called for settable values, so that
+// it can call the [reflect.Value] setters right away.
+type Sanitizer func(reflect.Value) error
+
+// RegisterSanitizer registers a new [Sanitizer] to the [DefaultValidator].
+func RegisterSanitizer(name string, fn Sanitizer, info ...CheckerInfo) {
+	DefaultValidator.RegisterSanitizer(name, fn, info...)
+}
+
+// RegisterSanitizer registers a new [Sanitizer] to the [Validator], usable in
+// tags like any other modifier, i.e. `validate:"trim,slug,required"`. The
+// sanitizers run in the order given, before the checks (and the default, if
+// any). It panics with [ErrFrozen] if v is frozen (see [Validator.Freeze]).
+func (v *Validator) RegisterSanitizer(name string, fn Sanitizer, info ...CheckerInfo) {
+	v.RegisterChecker(name, noop, info...)
+
+	v.Lock()
+	defer v.Unlock()
+
+	v.mustNotBeFrozen(name)
+	v.sanitizers[name] = fn
+	v.markCustom(name)
+	v.invalidate()
+}
+
+// Sanitize sanitizes val using the [DefaultValidator].
+// See [Validator.Sanitize] for details.
+func Sanitize(val any, tags ...string) error {
+	return DefaultValidator.Sanitize(val, tags...)
+}
+
+// Sanitize runs just the sanitizers (and the defaults) of val, the same way
+// [Validator.Validate] would, without running any checks, i.e. to normalize
+// a value that is validated later on. Pass a pointer, so that it is settable.
+func (v *Validator) Sanitize(val any, tags ...string) (err error) {
+	tag := strings.Join(tags, v.CheckSep)
+	st := &state{sanitizeOnly: true}
+
+	return st.collected(v.validate(st, reflect.ValueOf(val), tag))
+}
+
+// stringSanitizer makes a [Sanitizer] applying fn to strings (only).
+func stringSanitizer(fn func(string) string) Sanitizer {
+	return func(val reflect.Value) (err error) {
+		if val.Kind() == reflect.String {
+			val.SetString(fn(val.String()))
+		}
+
+		return
+	}
+}
+
+// squish trims s and collapses its inner whitespace runs into single spaces.
+func squish(s string) string {
+	return strings.Join(strings.FieldsFunc(s, unicode.IsSpace), " ")
+}
+
+// mutate applies the modifiers of tag mutating val (through any pointers):
+// first the sanitizers, then the default (see applyDefault).
+func (v *Validator) mutate(st *state, val reflect.Value, tag string, scope ...string) (err error) {
+	name, err := v.sanitize(indirect(val), tag)
+	if err != nil {
+		return newFieldError(err, name, "", st.errorPath(scope), st.jsonPath)
+	}
+
+	if err = v.applyDefault(val, tag); err != nil {
+		if path := st.errorPath(scope); len(path) > 0 {
+			err = fmt.Errorf("%s: %w", strings.Join(path, v.PathSep), err)
+		}
+	}
+
+	return
+}
+
+// sanitize applies the sanitizers in tag to val, if settable, returning
+// the name of the failed one, if any.
+func (v *Validator) sanitize(val reflect.Value, tag string) (name string, err error) {
+	if !val.CanSet() || !v.hasSanitizers(tag) {
+		return
+	}
+
+	_, chkNames, err := v.parse(tag)
+	if err != nil {
+		return "", nil // Reported by the validation of tag itself.
+	}
+
+	for _, name = range chkNames {
+		fn, _ := lookup(v, name, func(v *Validator) map[string]Sanitizer { return v.sanitizers })
+		if fn == nil {
+			continue
+		}
+
+		if err = fn(val); err != nil {
+			return
+		}
+	}
+
+	return "", nil
+}
+
+// hasSanitizers reports whether tag may have any sanitizers, sparing
+// the parsing of most of the tags.
+func (v *Validator) hasSanitizers(tag string) (ok bool) {
+	if v.CaseInsensitive {
+		tag = strings.ToLower(tag)
+	}
+
+	for ; v != nil && !ok; v = v.parent {
+		unlock := v.rlock()
+		for name := range v.sanitizers {
+			if ok = strings.Contains(tag, name); ok {
+				break
+			}
+		}
+		unlock()
+	}
+
+	return
+}
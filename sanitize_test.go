@@ -0,0 +1,87 @@
+package vali
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRegisterSanitizer(t *testing.T) {
+	t.Parallel()
+
+	errNotString := errors.New("not a string")
+
+	v := New()
+	v.RegisterSanitizer("slug", func(val reflect.Value) error {
+		if val.Kind() != reflect.String {
+			return errNotString
+		}
+
+		val.SetString(strings.ReplaceAll(strings.ToLower(val.String()), " ", "-"))
+
+		return nil
+	}, CheckerInfo{Description: "turn into a slug"})
+
+	type post struct {
+		Slug  string `validate:"trim,slug,regex:^[a-z-]+$"`
+		Count int    `validate:"slug"`
+	}
+
+	x := post{Slug: " Hello World "}
+	if err := v.Validate(&x); !errors.Is(err, errNotString) || errString(err) != "Count: slug check failed: not a string" {
+		t.Fatalf("Expected %v got %v", errNotString, err)
+	}
+
+	if x.Slug != "hello-world" {
+		t.Fatalf("Expected the slug to be sanitized got %q", x.Slug)
+	}
+
+	if info, _ := v.DescribeChecker("slug"); info.Description != "turn into a slug" {
+		t.Fatalf("Unexpected info %#v", info)
+	}
+
+	c := v.Child()
+	if s := "A B"; c.Validate(&s, "slug") != nil || s != "a-b" {
+		t.Fatalf("Expected the child to inherit the sanitizer got %q", s)
+	}
+
+	if s := "A B"; NewFrom(v).Validate(&s, "slug") != nil || s != "a-b" {
+		t.Fatalf("Expected the sanitizer to be merged got %q", s)
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	t.Parallel()
+
+	type (
+		address struct {
+			City string `validate:"required"`
+		}
+
+		user struct {
+			Name    string   `validate:"squish,required,min:5"`
+			Role    string   `validate:"trim,default:user,one_of:admin|user"`
+			Address *address `validate:"nil_struct:error"`
+			Tags    []struct {
+				Name string `validate:"upper"`
+			}
+		}
+	)
+
+	u := user{Name: "  J  D ", Role: "  ", Tags: []struct {
+		Name string `validate:"upper"`
+	}{{"a"}}}
+
+	if err := Sanitize(&u); err != nil {
+		t.Fatal(err)
+	}
+
+	if u.Name != "J D" || u.Role != "user" || u.Tags[0].Name != "A" {
+		t.Fatalf("Unexpected sanitized values %+v", u)
+	}
+
+	if err := Validate(&u); errString(err) != "Name: min check failed: len 3 is less than 5" {
+		t.Fatalf("Expected the checks to run only when validating, got %v", err)
+	}
+}
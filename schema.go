@@ -0,0 +1,494 @@
+package vali
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schema is the subset of a JSON Schema (draft 2020-12) document
+// that can be derived from validation tags. It doubles as an OpenAPI 3.1
+// schema object, as the two are compatible.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Const                any                `json:"const,omitempty"`
+	Not                  *Schema            `json:"not,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	MinProperties        *int               `json:"minProperties,omitempty"`
+	MaxProperties        *int               `json:"maxProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+}
+
+// JSONSchemaDraft is the $schema URI set on the generated documents.
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// schemaFormats maps checkers to their JSON Schema format.
+var schemaFormats = map[string]string{
+	"email":  "email",
+	"uuid":   "uuid",
+	"url":    "uri",
+	"ipv4":   "ipv4",
+	"ipv6":   "ipv6",
+	"domain": "hostname",
+}
+
+// schemaPatterns maps checkers to an equivalent (ECMA 262 compatible) pattern.
+var schemaPatterns = map[string]string{
+	"alpha":       `^[a-zA-Z]*$`,
+	"alphanum":    `^[a-zA-Z0-9]*$`,
+	"numeric":     `^\d*$`,
+	"hexadecimal": `^[0-9a-fA-F]+$`,
+	"mongoid":     `^[0-9a-fA-F]{24}$`,
+	"dns_label":   `^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`,
+	"base64":      `^(?:[a-zA-Z0-9+/]{4})*(?:[a-zA-Z0-9+/]{2}==|[a-zA-Z0-9+/]{3}=)?$`,
+	"base58":      `^[1-9A-HJ-NP-Za-km-z]+$`,
+}
+
+// schemaGen holds the state of a schema generation.
+type schemaGen struct {
+	*Validator
+
+	// seen holds the structs currently being expanded (to break cycles).
+	seen map[reflect.Type]bool
+
+	// defs collects the named structs as reusable components (OpenAPI),
+	// when nil all the structs are inlined.
+	defs map[string]*Schema
+
+	// names holds the names of the components of defs, by type.
+	names map[reflect.Type]string
+}
+
+// OpenAPIRefPrefix is the prefix of the references to component schemas.
+const OpenAPIRefPrefix = "#/components/schemas/"
+
+// JSONSchema generates a JSON Schema for val using the [DefaultValidator].
+// See [Validator.JSONSchema] for details.
+func JSONSchema(val any) (*Schema, error) {
+	return DefaultValidator.JSONSchema(val)
+}
+
+// JSONSchema generates a JSON Schema document describing val (a value,
+// a pointer or a [reflect.Type]) and the rules from its validation tags.
+//
+// Checkers with a JSON Schema equivalent (required, min, max, eq, ne, between,
+// regex, one_of, subset, required_keys and the formats/patterns of some of the
+// builtins) are translated, everything else is silently left out. Property names
+// follow the `json` tag, if present. Recursive types are only expanded once.
+func (v *Validator) JSONSchema(val any) (s *Schema, err error) {
+	t, err := typeOf(val)
+	if err != nil {
+		return
+	}
+
+	g := &schemaGen{Validator: v, seen: map[reflect.Type]bool{}}
+	if s, err = g.schemaOf(t); err != nil {
+		return
+	}
+
+	s.Schema = JSONSchemaDraft
+
+	return
+}
+
+// OpenAPISchemas generates OpenAPI component schemas using the [DefaultValidator].
+// See [Validator.OpenAPISchemas] for details.
+func OpenAPISchemas(vals ...any) (map[string]*Schema, error) {
+	return DefaultValidator.OpenAPISchemas(vals...)
+}
+
+// OpenAPISchemas generates the OpenAPI 3.1 component schemas (the
+// `components.schemas` object) for the given named structs (values,
+// pointers or [reflect.Type]s) and all the named structs they reference.
+//
+// The schemas are the same as the ones produced by [Validator.JSONSchema],
+// except that nested named structs are referenced (see [OpenAPIRefPrefix])
+// rather than inlined. The components are named after the structs, the names
+// taken by structs of other packages being qualified by the package path.
+func (v *Validator) OpenAPISchemas(vals ...any) (defs map[string]*Schema, err error) {
+	g := &schemaGen{
+		Validator: v, seen: map[reflect.Type]bool{},
+		defs: map[string]*Schema{}, names: map[reflect.Type]string{},
+	}
+
+	for _, val := range vals {
+		var t reflect.Type
+
+		if t, err = typeOf(val); err != nil {
+			return
+		}
+
+		if t.Kind() != reflect.Struct || t.Name() == "" {
+			return nil, fmt.Errorf("cannot generate component schema for %s (not a named struct)", t)
+		}
+
+		if _, err = g.schemaOf(t); err != nil {
+			return
+		}
+	}
+
+	return g.defs, nil
+}
+
+func typeOf(val any) (t reflect.Type, err error) {
+	t, ok := val.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(val)
+	}
+
+	if t == nil {
+		return nil, errors.New("cannot generate schema for nil")
+	}
+
+	return indirectType(t), nil
+}
+
+func (g *schemaGen) schemaOf(t reflect.Type) (s *Schema, err error) {
+	t, s = indirectType(t), &Schema{}
+	if t.Kind() == reflect.Struct && isSQLNull(t) {
+		return g.schemaOf(t.Field(0).Type)
+	}
+
+	switch {
+	case t == timeType:
+		s.Type, s.Format = "string", "date-time"
+		return
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		s.Type = "string"
+		return
+	}
+
+	switch t.Kind() { //nolint:exhaustive // everything else is "any"
+	case reflect.String:
+		s.Type = "string"
+	case reflect.Bool:
+		s.Type = "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		s.Type = "integer"
+	case reflect.Float32, reflect.Float64:
+		s.Type = "number"
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			s.Type = "string"
+			return
+		}
+
+		s.Type = "array"
+		s.Items, err = g.schemaOf(t.Elem())
+	case reflect.Map:
+		s.Type = "object"
+		s.AdditionalProperties, err = g.schemaOf(t.Elem())
+	case reflect.Struct:
+		if g.defs != nil && t.Name() != "" {
+			return g.componentRef(t)
+		}
+
+		s.Type = "object"
+		if g.seen[t] {
+			return
+		}
+
+		g.seen[t] = true
+		defer delete(g.seen, t)
+
+		err = g.structSchema(t, s)
+	}
+
+	return
+}
+
+// componentRef returns a reference to the component schema of t,
+// generating it first, if needed.
+func (g *schemaGen) componentRef(t reflect.Type) (s *Schema, err error) {
+	name, ok := g.names[t]
+	if ok {
+		return &Schema{Ref: OpenAPIRefPrefix + name}, nil
+	}
+
+	name = g.componentName(t)
+	s = &Schema{Ref: OpenAPIRefPrefix + name}
+
+	def := &Schema{Type: "object"}
+	g.defs[name], g.names[t] = def, name
+
+	err = g.structSchema(t, def)
+
+	return
+}
+
+// componentName returns a new component name for t: its type name or, if
+// that is taken by a type of another package, its package qualified name
+// (followed by a counter, for the same-named types local to functions).
+func (g *schemaGen) componentName(t reflect.Type) (name string) {
+	if name = componentChars(t.Name()); g.defs[name] == nil {
+		return
+	}
+
+	name = componentChars(t.PkgPath() + "." + t.Name())
+	for base, i := name, 2; g.defs[name] != nil; i++ {
+		name = base + "_" + strconv.Itoa(i)
+	}
+
+	return
+}
+
+// componentChars replaces the chars of name not allowed
+// in OpenAPI component names.
+func componentChars(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
+
+func (g *schemaGen) structSchema(t reflect.Type, s *Schema) (err error) {
+	rules, err := g.typeRules(t)
+	if err != nil {
+		return
+	}
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := g.fieldTag(f, rules)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+
+		if tag == "-" || name == "-" {
+			continue
+		}
+
+		// Promoted fields, same as encoding/json does.
+		if ft := indirectType(f.Type); f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			if err = g.structSchema(ft, s); err != nil {
+				return
+			}
+
+			continue
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		var fs *Schema
+
+		if fs, err = g.schemaOf(f.Type); err != nil {
+			return
+		}
+
+		var required bool
+
+		if required, err = g.applyRules(fs, tag); err != nil {
+			return fmt.Errorf("%s: %w", f.Name, err)
+		}
+
+		if s.Properties == nil {
+			s.Properties = map[string]*Schema{}
+		}
+
+		s.Properties[name] = fs
+
+		if required {
+			s.Required = append(s.Required, name)
+		}
+	}
+
+	return
+}
+
+//nolint:gocognit,cyclop // it's a long, but flat, switch
+func (v *Validator) applyRules(s *Schema, tag string) (required bool, err error) {
+	if _, _, err = v.parse(tag); err != nil {
+		return
+	}
+
+	// The schema describes the checks applying to all the groups.
+	checks, _ := v.splitChecks(v.groupChecks(tag, nil)) // Already validated by parse.
+
+	for _, ck := range checks {
+		name, arg, _ := strings.Cut(strings.TrimSpace(ck), v.CheckArgSep)
+		arg = unquote(arg)
+
+		if f, ok := schemaFormats[name]; ok {
+			s.Format = f
+		}
+
+		if rx, ok := schemaPatterns[name]; ok {
+			s.Pattern = rx
+		}
+
+		switch name {
+		case "required":
+			required = true
+
+			switch s.Type {
+			case "string":
+				s.MinLength = cmpOrMax(s.MinLength, 1)
+			case "array":
+				s.MinItems = cmpOrMax(s.MinItems, 1)
+			case "object":
+				if s.Properties == nil {
+					s.MinProperties = cmpOrMax(s.MinProperties, 1)
+				}
+			}
+		case "regex":
+			s.Pattern = arg
+		case "one_of":
+			for _, x := range v.ParseArgs(arg) {
+				s.Enum = append(s.Enum, schemaValue(s.Type, x))
+			}
+		case "enum":
+			values, _ := lookup(v, arg, func(v *Validator) map[string][]any { return v.enums })
+			s.Enum = append(s.Enum, values...)
+		case "not_one_of":
+			if s.Not == nil {
+				s.Not = &Schema{}
+			}
+
+			for _, x := range v.ParseArgs(arg) {
+				s.Not.Enum = append(s.Not.Enum, schemaValue(s.Type, x))
+			}
+		case "subset":
+			if s.Items != nil && s.Items.Type != "object" {
+				for _, x := range v.ParseArgs(arg) {
+					s.Items.Enum = append(s.Items.Enum, schemaValue(s.Items.Type, x))
+				}
+			}
+		case "required_keys":
+			if s.Type == "object" {
+				s.Required = append(s.Required, v.ParseArgs(arg)...)
+			}
+		case "min", "max", "eq", "ne":
+			if s.Format != "date-time" { // No standard equivalent for times.
+				err = s.applyCmp(name, arg)
+			}
+		case "between":
+			if args := v.ParseArgs(arg); s.Format != "date-time" && len(args) == 2 {
+				if err = s.applyCmp("min", args[0]); err == nil {
+					err = s.applyCmp("max", args[1])
+				}
+			}
+		}
+
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (s *Schema) applyCmp(name, arg string) (err error) {
+	if s.Type == "integer" || s.Type == "number" {
+		var n float64
+
+		if n, err = strconv.ParseFloat(arg, 64); err != nil {
+			return
+		}
+
+		switch name {
+		case "min":
+			s.Minimum = &n
+		case "max":
+			s.Maximum = &n
+		case "eq":
+			s.Const = schemaValue(s.Type, arg)
+		case "ne":
+			s.Not = &Schema{Const: schemaValue(s.Type, arg)}
+		}
+
+		return
+	}
+
+	var n int
+
+	if n, err = strconv.Atoi(arg); err != nil {
+		return
+	}
+
+	lo, hi := &s.MinLength, &s.MaxLength
+
+	switch s.Type {
+	case "array":
+		lo, hi = &s.MinItems, &s.MaxItems
+	case "object":
+		lo, hi = &s.MinProperties, &s.MaxProperties
+	}
+
+	switch name {
+	case "min":
+		*lo = &n
+	case "max":
+		*hi = &n
+	case "eq":
+		*lo, *hi = &n, &n
+	}
+
+	return
+}
+
+// schemaValue converts x to the JSON type t, if possible.
+func schemaValue(t, x string) any {
+	switch t {
+	case "integer":
+		if n, err := strconv.ParseInt(x, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if n, err := strconv.ParseFloat(x, 64); err == nil {
+			return n
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(x); err == nil {
+			return b
+		}
+	}
+
+	return x
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t
+}
+
+func cmpOrMax(x *int, n int) *int {
+	if x != nil && *x > n {
+		return x
+	}
+
+	return &n
+}
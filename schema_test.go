@@ -0,0 +1,325 @@
+package vali
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/url"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+type (
+	schemaAddress struct {
+		Street string `json:"street" validate:"required,max:64"`
+		Zip    string `json:"zip"    validate:"numeric,eq:5"`
+	}
+
+	schemaAudit struct {
+		CreatedAt time.Time `json:"created_at" validate:"required"`
+	}
+
+	schemaUser struct {
+		schemaAudit
+
+		ID       string            `json:"id"                 validate:"required,uuid"`
+		Email    string            `json:"email"              validate:"required,email"`
+		Age      int               `json:"age,omitempty"      validate:"min:18,max:130"`
+		Score    float64           `json:"score"              validate:"ne:0"`
+		Status   string            `json:"status"             validate:"one_of:active|inactive"`
+		Level    int               `json:"level"              validate:"one_of:1|2|3"`
+		Nick     string            `json:"nick"               validate:"not_one_of:admin|root"`
+		Code     string            `json:"code"               validate:"regex:^[A-Z]{3}$"`
+		Tags     []string          `json:"tags"               validate:"required,max:5,subset:a|b|c"`
+		Meta     map[string]string `json:"meta"               validate:"min:1,required_keys:source"`
+		Home     *schemaAddress    `json:"home"`
+		Friends  []*schemaUser     `json:"friends,omitempty"`
+		Avatar   []byte            `json:"avatar"`
+		Internal string            `json:"-"                  validate:"required"`
+		Skipped  string            `validate:"-"`
+		Custom   string            `json:"custom"             validate:"luhn"`
+		private  string            `validate:"required"`
+	}
+)
+
+func TestJSONSchema(t *testing.T) {
+	t.Parallel()
+
+	exp := `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "age": {
+      "type": "integer",
+      "minimum": 18,
+      "maximum": 130
+    },
+    "avatar": {
+      "type": "string"
+    },
+    "code": {
+      "type": "string",
+      "pattern": "^[A-Z]{3}$"
+    },
+    "created_at": {
+      "type": "string",
+      "format": "date-time",
+      "minLength": 1
+    },
+    "custom": {
+      "type": "string"
+    },
+    "email": {
+      "type": "string",
+      "format": "email",
+      "minLength": 1
+    },
+    "friends": {
+      "type": "array",
+      "items": {
+        "type": "object"
+      }
+    },
+    "home": {
+      "type": "object",
+      "properties": {
+        "street": {
+          "type": "string",
+          "minLength": 1,
+          "maxLength": 64
+        },
+        "zip": {
+          "type": "string",
+          "pattern": "^\\d*$",
+          "minLength": 5,
+          "maxLength": 5
+        }
+      },
+      "required": [
+        "street"
+      ]
+    },
+    "id": {
+      "type": "string",
+      "format": "uuid",
+      "minLength": 1
+    },
+    "level": {
+      "type": "integer",
+      "enum": [
+        1,
+        2,
+        3
+      ]
+    },
+    "meta": {
+      "type": "object",
+      "minProperties": 1,
+      "additionalProperties": {
+        "type": "string"
+      },
+      "required": [
+        "source"
+      ]
+    },
+    "nick": {
+      "type": "string",
+      "not": {
+        "enum": [
+          "admin",
+          "root"
+        ]
+      }
+    },
+    "score": {
+      "type": "number",
+      "not": {
+        "const": 0
+      }
+    },
+    "status": {
+      "type": "string",
+      "enum": [
+        "active",
+        "inactive"
+      ]
+    },
+    "tags": {
+      "type": "array",
+      "minItems": 1,
+      "maxItems": 5,
+      "items": {
+        "type": "string",
+        "enum": [
+          "a",
+          "b",
+          "c"
+        ]
+      }
+    }
+  },
+  "required": [
+    "created_at",
+    "id",
+    "email",
+    "tags"
+  ]
+}`
+
+	for _, val := range []any{schemaUser{}, &schemaUser{}, (*schemaUser)(nil), reflect.TypeFor[schemaUser]()} {
+		s, err := JSONSchema(val)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		act, err := json.MarshalIndent(s, "", "  ")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(act) != exp {
+			t.Fatalf("Expected\n%s\ngot\n%s", exp, act)
+		}
+	}
+}
+
+func TestJSONSchemaErrors(t *testing.T) {
+	t.Parallel()
+
+	if _, err := JSONSchema(nil); err == nil {
+		t.Fatal("Expected error for nil")
+	}
+
+	_, err := JSONSchema(struct {
+		Foo string `validate:"bogus"`
+	}{})
+	if !errors.Is(err, ErrInvalidChecker) {
+		t.Fatalf("Expected %v got %v", ErrInvalidChecker, err)
+	}
+
+	_, err = JSONSchema(struct {
+		Foo int `validate:"min:foo"`
+	}{})
+	if err == nil {
+		t.Fatal("Expected error for invalid min")
+	}
+}
+
+func TestOpenAPISchemas(t *testing.T) {
+	t.Parallel()
+
+	defs, err := OpenAPISchemas(schemaUser{}, reflect.TypeFor[*schemaAddress]())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(defs) != 2 {
+		t.Fatalf("Expected 2 schemas got %d", len(defs))
+	}
+
+	user := defs["schemaUser"]
+	if user == nil || user.Schema != "" || user.Type != "object" {
+		t.Fatalf("Unexpected user schema %#v", user)
+	}
+
+	if ref := user.Properties["home"].Ref; ref != "#/components/schemas/schemaAddress" {
+		t.Fatalf("Unexpected home ref %q", ref)
+	}
+
+	if ref := user.Properties["friends"].Items.Ref; ref != "#/components/schemas/schemaUser" {
+		t.Fatalf("Unexpected friends ref %q", ref)
+	}
+
+	if f := user.Properties["email"].Format; f != "email" {
+		t.Fatalf("Unexpected email format %q", f)
+	}
+
+	if _, ok := user.Properties["created_at"]; !ok {
+		t.Fatal("Expected promoted created_at")
+	}
+
+	if req := defs["schemaAddress"].Required; len(req) != 1 || req[0] != "street" {
+		t.Fatalf("Unexpected address required %v", req)
+	}
+}
+
+func TestOpenAPISchemasErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, val := range []any{nil, "foo", struct{}{}} {
+		if _, err := OpenAPISchemas(val); err == nil {
+			t.Fatalf("Expected error for %#v", val)
+		}
+	}
+
+	type bad struct {
+		Foo string `validate:"bogus"`
+	}
+
+	if _, err := OpenAPISchemas(bad{}); !errors.Is(err, ErrInvalidChecker) {
+		t.Fatalf("Expected %v got %v", ErrInvalidChecker, err)
+	}
+}
+
+func TestComponentName(t *testing.T) {
+	t.Parallel()
+
+	type page[T any] struct{ Items []T }
+
+	if act := componentChars(reflect.TypeFor[page[schemaUser]]().Name()); act != "page_github.com_alexaandru_vali.schemaUser_" {
+		t.Fatalf("Unexpected name %q", act)
+	}
+}
+
+func TestOpenAPISchemasCollisions(t *testing.T) {
+	t.Parallel()
+
+	type (
+		URL   struct{ Raw string }
+		links struct {
+			Self URL
+			Web  url.URL
+		}
+	)
+
+	// The same-named types local to functions share the package path, too.
+	other := func() reflect.Type {
+		type URL struct{ Path string }
+		return reflect.TypeFor[URL]()
+	}
+
+	another := func() reflect.Type {
+		type URL struct{ Host string }
+		return reflect.TypeFor[URL]()
+	}
+
+	defs, err := OpenAPISchemas(links{}, other(), another(), other(), reflect.TypeFor[URL]())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ref := defs["links"].Properties["Self"].Ref; ref != OpenAPIRefPrefix+"URL" {
+		t.Fatalf("Unexpected self ref %q", ref)
+	}
+
+	if ref := defs["links"].Properties["Web"].Ref; ref != OpenAPIRefPrefix+"net_url.URL" {
+		t.Fatalf("Unexpected web ref %q", ref)
+	}
+
+	for name, prop := range map[string]string{
+		"URL":                              "Raw",
+		"net_url.URL":                      "Scheme",
+		"github.com_alexaandru_vali.URL":   "Path",
+		"github.com_alexaandru_vali.URL_2": "Host",
+	} {
+		if _, ok := defs[name].Properties[prop]; !ok {
+			t.Fatalf("Expected %s in %s got %#v", prop, name, defs[name])
+		}
+	}
+
+	if len(defs) != 6 { // Along with url.Userinfo.
+		t.Fatalf("Unexpected schemas %v", slices.Sorted(maps.Keys(defs)))
+	}
+}
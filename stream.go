@@ -0,0 +1,75 @@
+package vali
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"iter"
+)
+
+// ValidateStream validates the items of seq as they come (i.e. the records of
+// a large import, which need not be materialized), using v (or the
+// [DefaultValidator], if nil) and ctx, the same as [Validator.ValidateContext]
+// does. Each item is passed to fn, along with its index and the validation
+// error (nil, if it passed). It stops when fn returns false or ctx is done,
+// returning the number of items validated and the context error, if any.
+func ValidateStream[T any](ctx context.Context, v *Validator, seq iter.Seq[T],
+	fn func(idx int, item T, err error) bool,
+) (n int, err error) {
+	if v == nil {
+		v = DefaultValidator
+	}
+
+	for item := range seq {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+
+		if n++; !fn(n-1, item, v.ValidateContext(ctx, item)) {
+			return
+		}
+	}
+
+	return
+}
+
+// DecodeStream decodes the (i.e. newline delimited) JSON values of dec into
+// T's and validates them as they are decoded, using v (or the [DefaultValidator],
+// if nil), the same as [Validator.ValidateJSON] does (so that the required
+// fields must be present). Each item is passed to fn, along with its index and
+// the validation error (nil, if it passed). It stops when fn returns false, ctx
+// is done or the input ends (i.e. on a malformed value), returning the number of
+// items decoded and the error stopping it, if any, other than [io.EOF]. For the
+// elements of a JSON array, read its opening token first, via [json.Decoder.Token].
+func DecodeStream[T any](ctx context.Context, v *Validator, dec *json.Decoder,
+	fn func(idx int, item T, err error) bool,
+) (n int, err error) {
+	if v == nil {
+		v = DefaultValidator
+	}
+
+	for dec.More() {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+
+		var raw json.RawMessage
+		if err = dec.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				err = nil
+			}
+
+			return
+		}
+
+		var item T
+
+		verr := v.ValidateJSON(raw, &item)
+		if n++; !fn(n-1, item, verr) {
+			return
+		}
+	}
+
+	return
+}
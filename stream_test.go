@@ -0,0 +1,98 @@
+package vali
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestValidateStream(t *testing.T) {
+	t.Parallel()
+
+	type row struct {
+		Email string `validate:"required,email"`
+	}
+
+	var failed []int
+
+	collect := func(i int, _ row, err error) bool {
+		if err != nil {
+			failed = append(failed, i)
+		}
+
+		return true
+	}
+
+	rows := []row{{"a@b.c"}, {""}, {"x@y.z"}, {"nope"}}
+
+	n, err := ValidateStream(context.Background(), nil, slices.Values(rows), collect)
+	if err != nil || n != 4 || !slices.Equal(failed, []int{1, 3}) {
+		t.Fatalf("Unexpected %d, %v, %v", n, failed, err)
+	}
+
+	n, _ = ValidateStream(context.Background(), New(), slices.Values(rows), func(_ int, _ row, err error) bool {
+		return err == nil
+	})
+	if n != 2 {
+		t.Fatalf("Expected to stop at the second item got %d", n)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if n, err = ValidateStream(ctx, nil, slices.Values(rows), collect); n != 0 || !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected %v got %d, %v", context.Canceled, n, err)
+	}
+}
+
+func TestDecodeStream(t *testing.T) {
+	t.Parallel()
+
+	type row struct {
+		Email string `json:"email" validate:"required,email"`
+		Admin bool   `json:"admin" validate:"required"`
+	}
+
+	var (
+		valid  []string
+		failed []int
+	)
+
+	collect := func(i int, r row, err error) bool {
+		if err != nil {
+			failed = append(failed, i)
+		} else {
+			valid = append(valid, r.Email)
+		}
+
+		return true
+	}
+
+	input := `{"email": "a@b.c", "admin": false}
+{"email": "x@y.z"}
+{"email": "nope", "admin": true}
+{"email": "d@e.f", "admin": true}
+`
+
+	n, err := DecodeStream(context.Background(), nil, json.NewDecoder(strings.NewReader(input)), collect)
+	if err != nil || n != 4 || !slices.Equal(failed, []int{1, 2}) || !slices.Equal(valid, []string{"a@b.c", "d@e.f"}) {
+		t.Fatalf("Unexpected %d, %v, %v, %v", n, valid, failed, err)
+	}
+
+	dec := json.NewDecoder(strings.NewReader(`[{"email": "a@b.c", "admin": true}, {"email": "x@y.z", "admin": true}]`))
+	if _, err = dec.Token(); err != nil {
+		t.Fatal(err)
+	}
+
+	if n, err = DecodeStream(context.Background(), New(), dec, collect); err != nil || n != 2 {
+		t.Fatalf("Expected the 2 array elements got %d, %v", n, err)
+	}
+
+	dec = json.NewDecoder(strings.NewReader(`{"email": "a@b.c", "admin": true} {"email":`))
+	if n, err = DecodeStream(context.Background(), nil, dec, collect); err == nil || n != 1 {
+		t.Fatalf("Expected to stop at the malformed record got %d, %v", n, err)
+	}
+}
@@ -6,53 +6,302 @@
 //
 // You can pass it a struct, a *struct, a *****struct, doesn't matter,
 // it will always fast-forward to the value and ignore any pointers.
+// The same goes for interfaces (the dynamic value is validated) and
+// the database/sql nullable types (sql.NullString, etc.).
 //
 // It is very small, but extensible, you can easily add your own checkers
 // or "checker makers" (basically, checkers that can take arguments).
 package vali
 
 import (
+	"context"
+	"encoding"
+	"errors"
 	"fmt"
+	"log/slog"
+	"maps"
+	"math/big"
+	"net/netip"
 	"reflect"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+	"unicode"
 )
 
 type (
+	// state holds the state of a single validation call.
+	state struct {
+		// groups holds the groups being validated (see [Validator.ValidateGroup]).
+		groups []string
+
+		// locale, failFast and maxErrors hold the per call settings (see [Opts]).
+		locale    string
+		failFast  bool
+		maxErrors int
+
+		// report collects the outcomes of the checks, when set (see [Validator.Report]).
+		report *Outcomes
+
+		// sanitizeOnly is set when only sanitizing (see [Validator.Sanitize]).
+		sanitizeOnly bool
+
+		// present holds the paths of the fields present in the input,
+		// when known (see [Validator.ValidateJSON]), joined by sep.
+		present map[string]bool
+		sep     string
+
+		// partial is set when only the present fields (and their own fields
+		// or elements) are validated (see [Validator.ValidatePartial]).
+		partial bool
+
+		// parent holds the struct enclosing the field being validated,
+		// used by the checks referencing sibling fields (i.e. expr).
+		parent reflect.Value
+
+		// ctx is the context passed to [Validator.ValidateContext], if any.
+		ctx context.Context //nolint:containedctx // scoped to a single call
+
+		// promoted holds the checks an embedded field promotes to the
+		// fields of the struct about to be validated (see promote).
+		promoted string
+
+		// errs holds the check failures collected so far (see [Validator.MaxErrors]).
+		errs Errors
+
+		// jsonPath holds the JSON names of the fields (and the indexes or keys
+		// of the elements) leading to the value being validated.
+		jsonPath []string
+
+		// root is the name of the struct type validated, when the error
+		// paths start with it (see [Validator.RootTypeName]).
+		root string
+	}
+
 	// Checker repesents a basic checker (one that takes no arguments, i.e. "required").
 	Checker func(reflect.Value) error
 
+	// Field is what the checkers registered with [CheckerInfo.Parent] set are
+	// passed (as a [reflect.Value]): the value being checked, along with the
+	// struct enclosing it (or the value itself, at the top level).
+	Field struct {
+		Value, Parent reflect.Value
+	}
+
+	// ContextChecker is a checker that needs a context, i.e. because it touches
+	// the network. It gets the context passed to [Validator.ValidateContext]
+	// (or [context.Background], when called via [Validator.Validate]).
+	ContextChecker func(context.Context, reflect.Value) error
+
 	// CheckerMaker is a way to construct checkers with arguments (i.e. "regex:^[A-Z]$").
 	CheckerMaker func(args string) (Checker, error)
 
+	// Comparer can be implemented by (i.e. decimal) number types, to be
+	// compared by eq, ne, min and max without going through float64.
+	// CompareString should return -1, 0 or +1 depending on whether the
+	// value is less than, equal to or greater than arg.
+	Comparer interface {
+		CompareString(arg string) (int, error)
+	}
+
+	// cached is a checker parsed from a tag, along with the registry generation
+	// (see [Validator.generation]) it was parsed against.
+	cached struct {
+		ck  Checker
+		gen uint64
+	}
+
+	// Metrics receives the outcome of each validation, i.e. to export counters
+	// of the checks rejecting the most values (see [Validator.Metrics]). The
+	// type names are the ones returned by [reflect.Type.String] (pointers
+	// dereferenced, i.e. "api.CreateUserRequest").
+	Metrics interface {
+		// Passed is called when a value of type typ passes the validation.
+		Passed(typ string)
+
+		// Failed is called for each check failure (more than one per validation,
+		// when they are collected, see [Validator.MaxErrors]), with the path
+		// to the failing field and the name of the failed check. The other
+		// errors (i.e. invalid checkers) are not reported, either way.
+		Failed(typ, field, check string)
+	}
+
+	// Extractor returns the value wrapped by val (i.e. by an optional type,
+	// like Option[T], or a protobuf wrapper), to be validated instead of val
+	// by all the checkers. It returns nil for an absent value.
+	Extractor func(val reflect.Value) any
+
+	// NilStructPolicy tells how the nil pointers to structs fields are
+	// validated (see [Validator.NilStructs]).
+	NilStructPolicy int
+
 	// Validator holds the validation context.
 	// You can create your own or use the default one provided by this library.
 	Validator struct {
 		checkers      map[string]Checker
+		ctxCheckers   map[string]ContextChecker
 		checkerMakers map[string]CheckerMaker
-		tag           string
+		sanitizers    map[string]Sanitizer
+		schemas       map[string]*Schema     // See [Validator.RegisterJSONSchema].
+		enums         map[string][]any       // See [Validator.RegisterEnum].
+		infos         map[string]CheckerInfo // Registered along with the checkers.
+		rules         Rules
+		tags          []string
+		custom        map[string]bool // The names registered after New.
+		parent        *Validator      // The validator to fall back to (see [Validator.Child]).
+		cache         sync.Map        // The checkers parsed from tags.
+		gen           atomic.Uint64   // Bumped by each registration, to invalidate the cache.
+		frozen        atomic.Bool
 
-		// Separator between checks (a), cheks and their arguments (b). The check between
-		// arguments themselves is not configurable (c), as that is ultimately up to each
-		// individual checker (how to parse the arguments). The only builtin check that uses
-		// it is `one_of` and that one requires it to be the pipe symbol.
+		// Separator between checks (a), cheks and their arguments (b) and between
+		// the arguments themselves (c). The latter is ultimately up to each individual
+		// checker maker (how to parse the arguments), but the builtin ones (`one_of`,
+		// `csv`, `between`) and the ones using [Validator.ParseArgs] honor it.
 		//
-		//     `validate:"required(a)uuid(a)one_of(b)foo|bar|baz"` which defaults to:
+		//     `validate:"required(a)uuid(a)one_of(b)foo(c)bar(c)baz"` which defaults to:
 		//     `validate:"required,uuid,one_of:foo|bar|baz"`
 		CheckSep,
-		CheckArgSep string
+		CheckArgSep,
+		CheckArgListSep string
+
+		// Separator between alternative checks, i.e. `validate:"ipv4|ipv6"` passes
+		// if any of them does. A check taking arguments swallows the rest of the
+		// alternation (so that `one_of:foo|bar` keeps working), therefore it must
+		// come last. Set it to "" to disable alternations altogether.
+		OrSep string
+
+		// Separator between a check and the groups it is restricted to, i.e.
+		// `validate:"required@create,uuid"` requires the field only when
+		// validating the "create" group (see [Validator.ValidateGroup]), while
+		// uuid applies to all of them. Multiple groups can be given, i.e.
+		// `required@create@import`. Set it to "" to disable the groups.
+		GroupSep string
+
+		// Separator between the fields of the error paths (see [FieldError.Field]),
+		// i.e. `Address.City`. The elements are appended to the paths as indexes,
+		// i.e. `Items[2]` for slices and arrays, or `Meta["owner"]` for maps
+		// (with the string keys quoted and the other ones as they are).
+		PathSep string
 
 		// Checks in this list WILL be checked against the zero value.
 		// By default, checks are not run against the zero value, unless they
 		// are part of this list.
 		DontSkipZeroChecks []string
 
+		// When set, DontSkipZeroChecks is ignored and all the checks run against
+		// zero values, unless the field opts out via the `omitempty` (skip all checks
+		// for zero values) or `omitnil` (skip all checks for nil values) modifiers.
+		// The modifiers can be used regardless, with the same effect on their fields.
+		ExplicitOmitEmpty bool
+
+		// When set, the checker names in tags are resolved case insensitively,
+		// i.e. `Required,UUID` resolves to `required,uuid`. The arguments are
+		// left as they are. An exact match is always preferred.
+		CaseInsensitive bool
+
+		// When non-zero, the validation goes on past the first failure, collecting
+		// the errors of up to MaxErrors fields (any number of them, if negative),
+		// as [Errors]. Past that, it stops, noting the truncation with an error
+		// wrapping [ErrTooManyErrors] (joined with the [Errors]). Only the check
+		// failures are collected, any other error (i.e. an invalid checker) stops
+		// it right away.
+		MaxErrors int
+
+		// How the nil pointers to structs fields are validated: skipped (the
+		// default), validated as zero structs (i.e. their required fields fail)
+		// or failed right away. It can be overridden per field, via the
+		// `nil_struct:skip`, `nil_struct:zero` or `nil_struct:error` modifiers.
+		NilStructs NilStructPolicy
+
+		// When set, `required` on a pointer field means that the pointer must
+		// not be nil (i.e. the field was supplied), while the value it points
+		// to may be zero, so that `"name": ""` is told apart from an absent key.
+		// By default, the pointers are dereferenced, so that it must not be zero.
+		RequiredPointers bool
+
+		// When set, the messages of the `required` failures tell whether the
+		// value was absent or empty (see [ErrAbsent]), i.e. `value missing (absent)`.
+		RequiredDetail bool
+
+		// When set, the error paths start with the name of the struct type being
+		// validated, i.e. `CreateUserRequest.Email` rather than just `Email`,
+		// which tells apart the errors of the types sharing field names (i.e.
+		// in logs). It does not apply to [FieldError.JSONPath].
+		RootTypeName bool
+
+		// When set, the checks of each field run in the ascending order of the
+		// priorities it returns for their names, i.e. the cheap ones before the
+		// ones touching the network, with the tag order breaking the ties.
+		// By default, they run in the tag order. The fields are validated in
+		// the declaration order, unless reordered via the `order:<n>` modifier.
+		CheckOrder func(check string) int
+
+		// When set, the outcome of each validation is reported to it.
+		Metrics Metrics
+
+		// When set, it renders the messages of the [FieldError]s returned by the
+		// validator, i.e. to control their prefixing, casing or punctuation in
+		// one place. It can call [FieldError.Error] for the default message.
+		ErrorFormatter func(FieldError) string
+
+		// When set, each check applied (or skipped) is logged at the debug level,
+		// along with the field path, its outcome and duration, so that it is easy
+		// to find out why a value passed (or not) the validation.
+		Logger *slog.Logger
+
 		sync.RWMutex //nolint:embeddedstructfieldcheck // ok
 	}
+
+	// Opts holds the settings of a single validation call (see [Validator.ValidateOpts]),
+	// i.e. the ones varying per request, so that the validator needn't be cloned.
+	Opts struct {
+		// Locale is passed on to the [FieldError]s, for [Validator.ErrorFormatter]
+		// to render their messages in, i.e. "fr".
+		Locale string
+
+		// Groups holds the groups to validate (see [Validator.ValidateGroup]).
+		Groups []string
+
+		// When set, the validation stops at the first failure, regardless of
+		// MaxErrors (or [Validator.MaxErrors]).
+		FailFast bool
+
+		// When non-zero, it overrides [Validator.MaxErrors].
+		MaxErrors int
+	}
 )
 
+// opaqueTypes holds the struct types that are validated as values,
+// without recursing into their (internal) fields.
+var opaqueTypes = map[reflect.Type]bool{
+	reflect.TypeFor[time.Time]():      true,
+	reflect.TypeFor[netip.Addr]():     true,
+	reflect.TypeFor[netip.Prefix]():   true,
+	reflect.TypeFor[netip.AddrPort](): true,
+	reflect.TypeFor[big.Int]():        true,
+	reflect.TypeFor[big.Float]():      true,
+	reflect.TypeFor[big.Rat]():        true,
+}
+
+// extractors holds the [Extractor]s registered by type.
+var extractors sync.Map
+
+// RegisterExtractor registers fn as the [Extractor] of the values of type t,
+// i.e. for wrapper types exposing their inner value to all the checkers.
+// It is global (the checkers are shared by all the validators), so it is
+// meant to be called at init time. Passing a nil fn unregisters it.
+func RegisterExtractor(t reflect.Type, fn Extractor) {
+	if fn == nil {
+		extractors.Delete(t)
+		return
+	}
+
+	extractors.Store(t, fn)
+}
+
 // DefaultValidatorTagName holds the default struct tag name.
 const DefaultValidatorTagName = "validate"
 
@@ -71,7 +320,7 @@ var DefaultValidator = New()
 //
 // In short, checks should be kept small, focused and composable and
 // avoid overlapping their responsibilities.
-var DefaultDontSkipZero = []string{"required", "eq", "ne", "min", "max"}
+var DefaultDontSkipZero = []string{"required", "eq", "ne", "min", "max", "between", "expr", "checksum"}
 
 // Interface returns the value as an interface{}, working around the limitation
 // that unexported fields cannot use [reflect.Value].Interface().
@@ -81,6 +330,10 @@ var DefaultDontSkipZero = []string{"required", "eq", "ne", "min", "max"}
 //
 // Returns nil if the value cannot be extracted (e.g., complex unexported types).
 func Interface(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+
 	if v.CanInterface() {
 		return v.Interface()
 	}
@@ -103,27 +356,79 @@ func Interface(v reflect.Value) any {
 	}
 }
 
+// String returns the string representation of the value, as used by
+// the string based checkers (email, regex, uuid, etc.). Values implementing
+// [encoding.TextMarshaler] or [fmt.Stringer] (in this order of preference)
+// are represented by those, the []byte-like ones (i.e. [encoding/json.RawMessage]) by
+// their contents and everything else is formatted with [fmt.Sprint].
+//
+// This is useful when implementing custom string based checkers.
+func String(v reflect.Value) string {
+	if !v.IsValid() || !v.CanInterface() {
+		return fmt.Sprint(Interface(v))
+	}
+
+	if k := v.Kind(); (k == reflect.Pointer || k == reflect.Interface) && v.IsNil() {
+		return fmt.Sprint(v.Interface())
+	}
+
+	xs := []any{v.Interface()}
+	if v.CanAddr() {
+		xs = append(xs, v.Addr().Interface())
+	}
+
+	for _, x := range xs {
+		if tm, ok := x.(encoding.TextMarshaler); ok {
+			if b, err := tm.MarshalText(); err == nil {
+				return string(b)
+			}
+		}
+	}
+
+	for _, x := range xs {
+		if s, ok := x.(fmt.Stringer); ok {
+			return s.String()
+		}
+	}
+
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+		return string(v.Bytes())
+	}
+
+	return fmt.Sprint(xs[0])
+}
+
 // New creates a new [Validator], initialized with the default checkers
 // and ready to be used. You can optionally pass a struct tag name or
-// use the [DefaultValidatorTagName].
+// use the [DefaultValidatorTagName]. Multiple tag names can be passed,
+// in the fallback order, i.e. New("validate", "binding") reads the `binding`
+// tag of the fields that have no `validate` tag, which eases migrations.
 //
-// By default, it errors out if it encounters validation tags on private
-// fields, but you can change that by setting the [Validator.ErrorOnPrivate]
-// to false. The error will be of type [ErrPrivateField].
-func New(opts ...string) (v *Validator) {
-	tag := DefaultValidatorTagName
-	if len(opts) > 0 {
-		tag = opts[0]
+// Private fields are validated just like the exported ones. A field tagged
+// `validate:"-"` (or given the "-" rule, see [Rules]) is never validated nor
+// recursed into, regardless of the other settings (i.e. [Validator.NilStructs]
+// or the promote modifier).
+func New(tags ...string) (v *Validator) {
+	if len(tags) == 0 {
+		tags = []string{DefaultValidatorTagName}
 	}
 
 	v = &Validator{
-		CheckSep: ",", CheckArgSep: ":",
-		tag:                tag,
+		CheckSep: ",", CheckArgSep: ":", CheckArgListSep: "|", OrSep: "|", GroupSep: "@", PathSep: ".",
+		tags:               tags,
 		checkers:           map[string]Checker{},
+		ctxCheckers:        map[string]ContextChecker{},
 		checkerMakers:      map[string]CheckerMaker{},
+		sanitizers:         map[string]Sanitizer{},
+		schemas:            map[string]*Schema{},
+		enums:              map[string][]any{},
+		infos:              map[string]CheckerInfo{},
 		DontSkipZeroChecks: DefaultDontSkipZero,
 	}
 
+	v.RegisterChecker("omitempty", noop)
+	v.RegisterChecker("omitnil", noop)
+	v.RegisterChecker("promote", noop)
 	v.RegisterChecker("required", required)
 	v.RegisterChecker("uuid", uuid)
 	v.RegisterChecker("email", email)
@@ -131,8 +436,13 @@ func New(opts ...string) (v *Validator) {
 	v.RegisterChecker("ipv4", ipv4)
 	v.RegisterChecker("ipv6", ipv6)
 	v.RegisterChecker("ip", ip)
+	v.RegisterChecker("cidr", cidr)
 	v.RegisterChecker("mac", mac)
 	v.RegisterChecker("domain", domain)
+	v.RegisterChecker("dns_label", dnsLabel)
+	v.RegisterChecker("k8s_name", k8sName)
+	v.RegisterChecker("k8s_label_value", k8sLabelValue)
+	v.RegisterChecker("k8s_qualified_name", k8sQualifiedName)
 	v.RegisterChecker("isbn", isbn)
 	v.RegisterChecker("alpha", alpha)
 	v.RegisterChecker("alphanum", alphaNum)
@@ -142,50 +452,370 @@ func New(opts ...string) (v *Validator) {
 	v.RegisterChecker("mongoid", mongoID)
 	v.RegisterChecker("hexadecimal", hexadecimal)
 	v.RegisterChecker("base64", base64)
+	v.RegisterChecker("base58", base58)
+	v.RegisterChecker("bech32", bech32)
 	v.RegisterChecker("json", jsoN)
+	v.RegisterChecker("json_object", jsonObject)
+	v.RegisterChecker("json_array", jsonArray)
+	v.RegisterChecker("geojson", geoJSON)
+	v.RegisterChecker("xml", xmL)
 	v.RegisterChecker("ascii", ascii)
 	v.RegisterChecker("lowercase", lowercase)
 	v.RegisterChecker("uppercase", uppercase)
+	v.RegisterChecker("no_html", noHTML)
+	v.RegisterChecker("html_escaped", htmlEscaped)
 	v.RegisterChecker("rgb", rgb)
 	v.RegisterChecker("rgba", rgba)
 	v.RegisterChecker("luhn", luhn)
 	v.RegisterChecker("ssn", ssn)
 	v.RegisterChecker("npi", npi)
+	v.RegisterChecker("nino", nino)
+	v.RegisterChecker("sin", sin)
+	v.RegisterChecker("lei", lei)
+	v.RegisterChecker("duns", duns)
+	v.RegisterChecker("git_sha", gitSHA)
+	v.RegisterChecker("git_url", gitURL)
+	v.RegisterChecker("cve", cve)
+	v.RegisterChecker("oid", oid)
+	v.RegisterChecker("us_state", usState)
+	v.RegisterChecker("calling_code", callingCode)
+	v.RegisterChecker("mod97", mod97)
+	v.RegisterChecker("pem", pemCheck)
+	v.RegisterChecker("x509_cert", x509Cert)
+	v.RegisterChecker("rsa_public_key", rsaPublicKey)
+	v.RegisterChecker("no_nil_elements", noNilElements)
+
+	v.RegisterSanitizer("trim", stringSanitizer(strings.TrimSpace))
+	v.RegisterSanitizer("lower", stringSanitizer(strings.ToLower))
+	v.RegisterSanitizer("upper", stringSanitizer(strings.ToUpper))
+	v.RegisterSanitizer("squish", stringSanitizer(squish))
 
 	v.RegisterCheckerMaker("regex", Regex)
 	v.RegisterCheckerMaker("eq", Eq)
 	v.RegisterCheckerMaker("ne", Ne)
 	v.RegisterCheckerMaker("min", Min)
 	v.RegisterCheckerMaker("max", Max)
-	v.RegisterCheckerMaker("one_of", oneOf)
+	v.RegisterCheckerMaker("num_eq", numCmp(expEq))
+	v.RegisterCheckerMaker("num_ne", numCmp(expNotEq))
+	v.RegisterCheckerMaker("num_min", numCmp(expMore))
+	v.RegisterCheckerMaker("num_max", numCmp(expLess))
+	v.RegisterCheckerMaker("entropy", entropy)
+	v.RegisterCheckerMaker("handle", handle)
+	v.RegisterCheckerMaker("git_sha", gitSHAOf)
+	v.RegisterCheckerMaker("msg", message)
+	v.RegisterCheckerMaker("default", defaultValue)
+	v.RegisterCheckerMaker("nil_struct", nilStruct)
+	v.RegisterCheckerMaker("order", order)
+	v.RegisterCheckerMaker("expr", expr)
+
+	for name, cm := range v.boundMakers() {
+		v.RegisterCheckerMaker(name, cm)
+	}
+
+	maps.Copy(v.infos, builtinInfos)
+
+	v.custom = map[string]bool{} // From now on, the registrations are custom.
 
 	return
 }
 
 // RegisterChecker registers a new [Checker] to the [DefaultValidator].
-func RegisterChecker(name string, fn Checker) {
-	DefaultValidator.RegisterChecker(name, fn)
+func RegisterChecker(name string, fn Checker, info ...CheckerInfo) {
+	DefaultValidator.RegisterChecker(name, fn, info...)
 }
 
-// RegisterChecker registers a new [Checker] to the [Validator].
-func (v *Validator) RegisterChecker(name string, fn Checker) {
+// RegisterChecker registers a new [Checker] to the [Validator], optionally
+// along with its description (see [Validator.DescribeChecker]).
+// It panics with [ErrFrozen] if v is frozen (see [Validator.Freeze]).
+func (v *Validator) RegisterChecker(name string, fn Checker, info ...CheckerInfo) {
 	v.Lock()
 	defer v.Unlock()
 
+	v.mustNotBeFrozen(name)
 	v.checkers[name] = fn
+	v.registerInfo(name, info)
+	v.markCustom(name)
+	v.invalidate()
+}
+
+// RegisterContextChecker registers a new [ContextChecker] to the [DefaultValidator].
+func RegisterContextChecker(name string, fn ContextChecker, info ...CheckerInfo) {
+	DefaultValidator.RegisterContextChecker(name, fn, info...)
+}
+
+// RegisterContextChecker registers a new [ContextChecker] to the [Validator].
+// It can be used in tags like any other checker, but it only gets the context
+// passed to [Validator.ValidateContext] when used on its own (not negated
+// nor as part of an alternation).
+func (v *Validator) RegisterContextChecker(name string, fn ContextChecker, info ...CheckerInfo) {
+	// The plain checker is the one used outside of [Validator.ValidateContext]
+	// and in negations or alternations, which are compiled once, for all calls.
+	v.RegisterChecker(name, func(val reflect.Value) error {
+		return fn(context.Background(), val) //nolint:forbidigo // there is no call context to pass
+	}, info...)
+
+	v.Lock()
+	defer v.Unlock()
+
+	v.mustNotBeFrozen(name)
+	v.ctxCheckers[name] = fn
+	v.markCustom(name)
+	v.invalidate()
 }
 
 // RegisterCheckerMaker registers a new [CheckerMaker] to the [DefaultValidator].
-func RegisterCheckerMaker(name string, fn CheckerMaker) {
-	DefaultValidator.RegisterCheckerMaker(name, fn)
+func RegisterCheckerMaker(name string, fn CheckerMaker, info ...CheckerInfo) {
+	DefaultValidator.RegisterCheckerMaker(name, fn, info...)
 }
 
-// RegisterCheckerMaker registers a new [CheckerMaker] to the [Validator].
-func (v *Validator) RegisterCheckerMaker(name string, fn CheckerMaker) {
+// RegisterCheckerMaker registers a new [CheckerMaker] to the [Validator],
+// optionally along with its description (see [Validator.DescribeChecker]),
+// the [CheckerInfo.Args] of which are shown when its arguments are invalid.
+// It panics with [ErrFrozen] if v is frozen (see [Validator.Freeze]).
+func (v *Validator) RegisterCheckerMaker(name string, fn CheckerMaker, info ...CheckerInfo) {
 	v.Lock()
 	defer v.Unlock()
 
+	v.mustNotBeFrozen(name)
 	v.checkerMakers[name] = fn
+	v.registerInfo(name, info)
+	v.markCustom(name)
+	v.invalidate()
+}
+
+// NewFrom creates a new [Validator] with the same settings (tag names,
+// separators, etc.) and registry (checkers, checker makers and rules) as
+// base, i.e. a company wide validator, which can then be extended further.
+func NewFrom(base *Validator) (v *Validator) {
+	v = New(base.tags...)
+	v.copySettings(base)
+	v.Merge(base)
+
+	return
+}
+
+// Child creates a validator with the same settings as v, which falls back to
+// the registry of v (checkers, checker makers and rules) for everything it
+// does not register itself, i.e. a per tenant validator, shadowing just a few
+// checkers or rules of a shared one. Nothing is copied, so it is cheap to
+// create, and the later registrations to v are visible to the child too.
+func (v *Validator) Child() (c *Validator) {
+	c = &Validator{
+		tags:          v.tags,
+		checkers:      map[string]Checker{},
+		ctxCheckers:   map[string]ContextChecker{},
+		checkerMakers: map[string]CheckerMaker{},
+		sanitizers:    map[string]Sanitizer{},
+		schemas:       map[string]*Schema{},
+		enums:         map[string][]any{},
+		infos:         map[string]CheckerInfo{},
+		parent:        v,
+	}
+
+	c.copySettings(v)
+
+	// The makers bound to their validator must resolve against the child (its
+	// separators and registry), unless v (or one of its parents) replaced them.
+	for name, cm := range c.boundMakers() {
+		if _, custom := lookup(v, name, func(v *Validator) map[string]bool { return v.custom }); !custom {
+			c.checkerMakers[name] = cm
+		}
+	}
+
+	c.custom = map[string]bool{}
+
+	return
+}
+
+// copySettings copies the (exported) settings of from into v.
+func (v *Validator) copySettings(from *Validator) {
+	v.CheckSep, v.CheckArgSep, v.CheckArgListSep, v.OrSep = from.CheckSep, from.CheckArgSep, from.CheckArgListSep, from.OrSep
+	v.GroupSep, v.PathSep = from.GroupSep, from.PathSep
+	v.DontSkipZeroChecks = slices.Clone(from.DontSkipZeroChecks)
+	v.ExplicitOmitEmpty, v.CaseInsensitive, v.Logger = from.ExplicitOmitEmpty, from.CaseInsensitive, from.Logger
+	v.MaxErrors, v.RootTypeName, v.Metrics = from.MaxErrors, from.RootTypeName, from.Metrics
+	v.ErrorFormatter, v.CheckOrder = from.ErrorFormatter, from.CheckOrder
+	v.NilStructs, v.RequiredPointers, v.RequiredDetail = from.NilStructs, from.RequiredPointers, from.RequiredDetail
+}
+
+// boundMakers returns the builtin checker makers which are methods of v,
+// as they depend on its separators or registry.
+func (v *Validator) boundMakers() map[string]CheckerMaker {
+	return map[string]CheckerMaker{
+		"between":       v.between,
+		"one_of":        v.oneOf,
+		"creditcard":    v.creditCardOf,
+		"us_state":      v.usStateOf,
+		"mod11":         v.mod11,
+		"checksum":      v.checksum,
+		"not_one_of":    v.notOneOf,
+		"subset":        v.subset,
+		"contains_all":  v.containsAll,
+		"required_keys": v.requiredKeys,
+		"csv":           v.csvRecord,
+		"not":           v.not,
+		"json_schema":   v.jsonSchema,
+		"enum":          v.enum,
+	}
+}
+
+// Merge copies the custom registrations (the checkers, context checkers and
+// checker makers registered after [New]) and the rules of other into v,
+// overriding the ones registered under the same names. The registry other
+// inherits (see [Validator.Child]) is not copied. It panics with [ErrFrozen]
+// if v is frozen.
+func (v *Validator) Merge(other *Validator) {
+	if v == other {
+		return
+	}
+
+	// Take a snapshot first, so that the two are never locked at once. The
+	// builtin entries are skipped, as v has its own (some are bound to their
+	// validator, i.e. `not`, which must resolve the names against v).
+	checkers, ctxCheckers, makers := map[string]Checker{}, map[string]ContextChecker{}, map[string]CheckerMaker{}
+	infos, sanitizers := map[string]CheckerInfo{}, map[string]Sanitizer{}
+
+	unlock := other.rlock()
+	for name := range other.custom {
+		if fn, ok := other.sanitizers[name]; ok {
+			sanitizers[name] = fn
+		}
+
+		if info, ok := other.infos[name]; ok {
+			infos[name] = info
+		}
+
+		if ck, ok := other.checkers[name]; ok {
+			checkers[name] = ck
+		}
+
+		if cc, ok := other.ctxCheckers[name]; ok {
+			ctxCheckers[name] = cc
+		}
+
+		if cm, ok := other.checkerMakers[name]; ok {
+			makers[name] = cm
+		}
+	}
+
+	rules, schemas, enums := other.rules, maps.Clone(other.schemas), maps.Clone(other.enums)
+	unlock()
+
+	v.Lock()
+	defer v.Unlock()
+
+	v.mustNotBeFrozen("merged checkers")
+
+	for name, ck := range checkers {
+		v.checkers[name], v.custom[name] = ck, true
+	}
+
+	for name, cc := range ctxCheckers {
+		v.ctxCheckers[name], v.custom[name] = cc, true
+	}
+
+	for name, cm := range makers {
+		v.checkerMakers[name], v.custom[name] = cm, true
+	}
+
+	for name, fn := range sanitizers {
+		v.sanitizers[name], v.custom[name] = fn, true
+	}
+
+	maps.Copy(v.infos, infos)
+	maps.Copy(v.schemas, schemas)
+	maps.Copy(v.enums, enums)
+
+	v.mergeRules(rules)
+	v.invalidate()
+}
+
+// Freeze makes the registry of v immutable: any further registration (of
+// checkers, checker makers or rules) panics with [ErrFrozen]. In return, the
+// validation switches to a lock-free read path, so call it once the setup is
+// complete, i.e. right after registering the custom checkers.
+func (v *Validator) Freeze() {
+	v.Lock()
+	defer v.Unlock()
+
+	v.frozen.Store(true)
+}
+
+// markCustom records name as a custom registration (see [Validator.Merge]).
+// It must be called with v locked.
+func (v *Validator) markCustom(name string) {
+	if v.custom != nil {
+		v.custom[name] = true
+	}
+}
+
+// mustNotBeFrozen panics if v is frozen. It must be called with v locked.
+func (v *Validator) mustNotBeFrozen(name string) {
+	if v.frozen.Load() {
+		panic(fmt.Errorf("%w: cannot register %s", ErrFrozen, name))
+	}
+}
+
+// rlock read locks v, unless frozen (when the registry cannot change anymore),
+// returning the matching unlock func.
+func (v *Validator) rlock() (unlock func()) {
+	if v.frozen.Load() {
+		return func() {}
+	}
+
+	v.RLock()
+
+	return v.RUnlock
+}
+
+// lookup returns the entry registered under name in the registry of v
+// or, failing that, of its closest parent having one.
+func lookup[T any](v *Validator, name string, registry func(*Validator) map[string]T) (t T, ok bool) {
+	for ; v != nil && !ok; v = v.parent {
+		unlock := v.rlock()
+		t, ok = registry(v)[name]
+		unlock()
+	}
+
+	return
+}
+
+// checker returns the checker registered (or cached) under name, if any.
+func (v *Validator) checker(name string) (ck Checker) {
+	if ck, _ = lookup(v, name, func(v *Validator) map[string]Checker { return v.checkers }); ck != nil {
+		return
+	}
+
+	if x, ok := v.cache.Load(name); ok {
+		if c, _ := x.(cached); c.gen == v.generation() {
+			ck = c.ck
+		}
+	}
+
+	return
+}
+
+// cacheChecker caches the checker parsed from tag. The cache is kept apart
+// from the registry, so that it can be written to even when v is frozen.
+func (v *Validator) cacheChecker(tag string, ck Checker) {
+	v.cache.Store(tag, cached{ck, v.generation()})
+}
+
+// invalidate drops the checkers cached by v. The ones cached by its children
+// are dropped lazily, as they no longer match the registry generation.
+func (v *Validator) invalidate() {
+	v.gen.Add(1)
+	v.cache.Clear()
+}
+
+// generation returns the registry generation of v, which changes with each
+// registration to v or to any of its parents.
+func (v *Validator) generation() (gen uint64) {
+	for ; v != nil; v = v.parent {
+		gen += v.gen.Load()
+	}
+
+	return
 }
 
 // Validate validates v against [DefaultValidator].
@@ -197,134 +827,1291 @@ func Validate(val any, tags ...string) error {
 // Validate validates a struct. The passed value v can be a value or
 // a pointer (or pointer to a pointer, although there's no point to do that in Go).
 // It will validate all the fields that have the `s.tag` present, recursively.
+// Slices, arrays and maps of structs are validated element wise, with the
+// error paths starting with the index (or key), i.e. `[3].Email`.
 func (v *Validator) Validate(val any, tags ...string) (err error) {
-	tag := strings.Join(tags, v.CheckSep)
+	return v.ValidateValue(reflect.ValueOf(val), strings.Join(tags, v.CheckSep))
+}
+
+// ValidateValue validates val against [DefaultValidator].
+// See [Validator.ValidateValue] for details.
+func ValidateValue(val reflect.Value, tag string) error {
+	return DefaultValidator.ValidateValue(val, tag)
+}
+
+// ValidateValue works like [Validator.Validate], for the callers already
+// holding a [reflect.Value] (i.e. ORMs or codecs), sparing them the round
+// trip through any. The value needs not be addressable nor exported.
+func (v *Validator) ValidateValue(val reflect.Value, tag string) (err error) {
+	st := &state{}
+
+	return v.observe(val, st.collected(v.validate(st, val, tag)))
+}
+
+// ValidateGroup validates val against [DefaultValidator], for the given groups.
+// See [Validator.ValidateGroup] for details.
+func ValidateGroup(val any, groups ...string) error {
+	return DefaultValidator.ValidateGroup(val, groups...)
+}
+
+// ValidateGroup works like [Validator.Validate], except that besides the checks
+// applying to all the groups, it runs the ones restricted (via [Validator.GroupSep])
+// to any of the given groups, i.e. the same struct can require an ID when
+// updated, but not when created. [Validator.Validate] runs only the former.
+func (v *Validator) ValidateGroup(val any, groups ...string) (err error) {
 	ref := reflect.ValueOf(val)
+	st := &state{groups: groups}
 
-	return v.validate(ref, tag)
+	return v.observe(ref, st.collected(v.validate(st, ref, "")))
 }
 
-func (v *Validator) validate(val reflect.Value, tag string, scope ...string) (err error) {
-	for val.Kind() == reflect.Pointer {
-		val = val.Elem()
+// ValidateOpts validates val against [DefaultValidator], with the given options.
+// See [Validator.ValidateOpts] for details.
+func ValidateOpts(val any, opts Opts) error {
+	return DefaultValidator.ValidateOpts(val, opts)
+}
+
+// ValidateOpts works like [Validator.Validate], with the per call settings in opts
+// (i.e. the locale or groups of the request) applied on top of the ones of v.
+func (v *Validator) ValidateOpts(val any, opts Opts) (err error) {
+	ref := reflect.ValueOf(val)
+	st := &state{groups: opts.Groups, locale: opts.Locale, failFast: opts.FailFast, maxErrors: opts.MaxErrors}
+
+	return v.observe(ref, st.collected(v.validate(st, ref, "")))
+}
+
+// ValidatePartial validates the given fields of val against [DefaultValidator].
+// See [Validator.ValidatePartial] for details.
+func ValidatePartial(val any, fields ...string) error {
+	return DefaultValidator.ValidatePartial(val, fields...)
+}
+
+// ValidatePartial works like [Validator.Validate], except that it only runs the
+// checks of the given fields (paths, as in the errors, i.e. "Address.City"),
+// and of their own fields and elements, i.e. for partial updates, where nothing
+// else is required. The checks referencing other fields (i.e. expr) see all of
+// them, so that the rules among the updated fields still apply.
+func (v *Validator) ValidatePartial(val any, fields ...string) (err error) {
+	ref := reflect.ValueOf(val)
+	st := &state{present: map[string]bool{}, sep: v.PathSep, partial: true}
+
+	for _, f := range fields {
+		st.present[f] = true
 	}
 
-	if tag != "" {
-		if err = v.validateScalar(val, tag, scope...); err != nil {
-			return
+	return v.observe(ref, st.collected(v.validate(st, ref, "")))
+}
+
+// ValidateAll validates each of the items against [DefaultValidator].
+// See [Validator.ValidateAll] for details.
+func ValidateAll(items any, tags ...string) BatchErrors {
+	return DefaultValidator.ValidateAll(items, tags...)
+}
+
+// ValidateAll validates each of the items (a slice or an array, or a pointer
+// to one) on its own, the same as [Validator.Validate] does, without stopping
+// at the failed ones, i.e. for bulk imports. It returns the errors by the index
+// of the failed items, or nil if all of them passed. Anything else than a
+// slice or an array is validated as a single item, at index 0.
+func (v *Validator) ValidateAll(items any, tags ...string) (errs BatchErrors) {
+	val, tag := indirect(reflect.ValueOf(items)), strings.Join(tags, v.CheckSep)
+	if k := val.Kind(); k != reflect.Slice && k != reflect.Array {
+		if err := v.Validate(items, tags...); err != nil {
+			errs = BatchErrors{0: err}
 		}
-	}
 
-	if val.Kind() != reflect.Struct {
 		return
 	}
 
-	for i := range val.NumField() {
-		iType := val.Type().Field(i)
-		reflTag := iType.Tag
-		tag = strings.TrimSpace(reflTag.Get(v.tag))
+	for i := range val.Len() {
+		if err := v.ValidateValue(val.Index(i), tag); err != nil {
+			if errs == nil {
+				errs = BatchErrors{}
+			}
 
-		if tag == "-" {
-			continue
+			errs[i] = err
 		}
+	}
 
-		iVal := val.Field(i)
-		for iVal.Kind() == reflect.Pointer {
-			iVal = iVal.Elem()
-		}
+	return
+}
 
-		if tag == "" && iVal.Kind() != reflect.Struct {
-			continue
-		}
+// ValidateContext validates v against [DefaultValidator], passing ctx to the
+// context checkers. See [Validator.ValidateContext] for details.
+func ValidateContext(ctx context.Context, val any, tags ...string) error {
+	return DefaultValidator.ValidateContext(ctx, val, tags...)
+}
 
-		iName := val.Type().Field(i).Name
-		localScope := append(scope, iName) //nolint:gocritic // ok
+// ValidateContext works like [Validator.Validate], except that ctx is passed
+// to the [ContextChecker]s (i.e. the network touching ones, like email_mx),
+// so that they can be canceled. It stops early once ctx is done.
+func (v *Validator) ValidateContext(ctx context.Context, val any, tags ...string) (err error) {
+	tag := strings.Join(tags, v.CheckSep)
+	ref := reflect.ValueOf(val)
 
-		err = v.validate(iVal, tag, localScope...)
-		if err != nil {
-			return
+	st := &state{ctx: ctx}
+
+	return v.observe(ref, st.collected(v.validate(st, ref, tag)))
+}
+
+// observe reports the outcome (err) of validating val to v.Metrics, if set.
+func (v *Validator) observe(val reflect.Value, err error) error {
+	if v.Metrics == nil {
+		return err
+	}
+
+	typ := "<nil>"
+	if val.IsValid() {
+		typ = indirectType(val.Type()).String()
+	}
+
+	var (
+		errs Errors
+		fe   *FieldError
+	)
+
+	switch {
+	case err == nil:
+		v.Metrics.Passed(typ)
+	case errors.As(err, &errs):
+		for _, fe := range errs {
+			v.Metrics.Failed(typ, fe.Field(), fe.Check)
 		}
+	case errors.As(err, &fe):
+		v.Metrics.Failed(typ, fe.Field(), fe.Check)
 	}
 
-	return
+	return err
 }
 
-func (v *Validator) validateScalar(val reflect.Value, tag string, scope ...string) (err error) {
-	defer func() {
-		if err != nil && len(scope) > 0 {
-			err = fmt.Errorf("%s: %w", strings.Join(scope, "."), err)
+func (v *Validator) validate(st *state, val reflect.Value, tag string, scope ...string) (err error) {
+	if st == nil {
+		st = &state{}
+	}
+
+	// The modifiers mutating val (i.e. trim or default) apply before anything else.
+	if tag = v.groupChecks(tag, st.groups); tag != "" {
+		if err = v.mutate(st, val, tag, scope...); err != nil {
+			return v.collect(st, err)
 		}
-	}()
+	}
 
-	checks, chkNames, err := v.parse(tag)
+	supplied := v.RequiredPointers && val.Kind() == reflect.Pointer && !val.IsNil()
+	val = indirect(val)
+
+	if len(scope) == 0 && v.RootTypeName && val.Kind() == reflect.Struct {
+		st.root = val.Type().Name()
+	}
+
+	if tag != "" && !st.sanitizeOnly {
+		var omitted bool
+
+		if omitted, err = v.validateScalar(st, val, tag, supplied, scope...); err != nil || omitted {
+			return v.collect(st, err)
+		}
+	}
+
+	// At the top level, the collections of structs are validated element wise.
+	if len(scope) == 0 && hasStructElems(val) {
+		return v.validateElems(st, val)
+	}
+
+	if val.Kind() != reflect.Struct || opaqueTypes[val.Type()] {
+		return
+	}
+
+	// The checks promoted by the embedding field (if any) apply to the fields
+	// of this struct only, not to the ones of the structs nested further.
+	promoted := st.promoted
+	st.promoted = ""
+
+	rules, err := v.typeRules(val.Type())
 	if err != nil {
 		return
 	}
 
-	for i, ck := range checks {
-		name := chkNames[i]
-		if strings.Contains(name, v.CheckArgSep) {
-			nx := strings.Split(name, v.CheckArgSep)
-			name = nx[0]
+	idx := v.fieldOrder(val.Type(), rules)
+
+	for n := range val.NumField() {
+		i := n
+		if idx != nil {
+			i = idx[n]
 		}
 
-		if isZero(val) && !slices.Contains(v.DontSkipZeroChecks, name) {
+		tag = v.fieldTag(val.Type().Field(i), rules)
+
+		if tag == "-" {
 			continue
 		}
 
-		if err = ck(val); err != nil {
-			return fmt.Errorf("%s %w: %w", name, ErrCheckFailed, err)
+		if promoted != "" && val.Type().Field(i).IsExported() {
+			tag = v.joinChecks(promoted, tag)
 		}
-	}
 
-	return
-}
+		iVal := indirect(val.Field(i))
+		nilStruct := nilStructType(val.Field(i))
 
-func (v *Validator) parse(tag string) (cx []Checker, cxNames []string, err error) {
-	for tag := range strings.SplitSeq(tag, v.CheckSep) {
-		tag = strings.TrimSpace(tag)
-		if tag == "" {
+		elems := hasStructElems(iVal)
+		if tag == "" && iVal.Kind() != reflect.Struct && !elems && !(nilStruct != nil && v.NilStructs != NilStructSkip) {
 			continue
 		}
 
-		v.RLock()
-		ck := v.checkers[tag]
-		v.RUnlock()
+		iName := val.Type().Field(i).Name
+		localScope := append(scope, iName) //nolint:gocritic // ok
 
-		switch {
-		case ck != nil:
-			cx = append(cx, ck)
-			cxNames = append(cxNames, tag)
-		case strings.Contains(tag, v.CheckArgSep):
-			tagz := strings.Split(tag, v.CheckArgSep)
-			if len(tagz) != 2 || tagz[0] == "" || tagz[1] == "" {
-				return nil, nil, fmt.Errorf("%w %s", ErrInvalidChecker, tag)
-			}
+		st.parent = val // Reset on every field, as nested structs change it.
+		leave := st.enter(jsonField(val.Type().Field(i)))
 
-			v.RLock()
-			cm := v.checkerMakers[tagz[0]]
-			v.RUnlock()
+		if val.Type().Field(i).Anonymous {
+			tag, st.promoted = v.promotedChecks(tag)
+		}
+
+		err = v.validate(st, val.Field(i), tag, localScope...)
+		if st.promoted = ""; err == nil && nilStruct != nil && !st.sanitizeOnly {
+			err = v.validateNilStruct(st, nilStruct, tag, localScope...)
+		}
+
+		if err == nil && elems {
+			err = v.validateElems(st, iVal, localScope...)
+		}
+
+		if leave(); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// promotedChecks splits the tag of an embedded field into the checks of the
+// field itself and the ones promoted to its fields, by the promote modifier.
+func (v *Validator) promotedChecks(tag string) (own, promoted string) {
+	checks, err := v.splitChecks(tag)
+	if err != nil {
+		return tag, "" // Reported by the validation of tag itself.
+	}
+
+	i := slices.IndexFunc(checks, func(ck string) bool { return strings.TrimSpace(ck) == "promote" })
+	if i < 0 {
+		return tag, ""
+	}
+
+	return "", strings.Join(slices.Delete(checks, i, i+1), v.CheckSep)
+}
+
+// groupChecks returns the checks of tag that apply to (any of) the groups:
+// the ones not restricted to some groups, and the ones restricted to them,
+// without their groups suffix.
+func (v *Validator) groupChecks(tag string, groups []string) string {
+	if v.GroupSep == "" || !strings.Contains(tag, v.GroupSep) {
+		return tag
+	}
+
+	checks, err := v.splitChecks(tag)
+	if err != nil {
+		return tag // Reported by the validation of tag itself.
+	}
+
+	kept := checks[:0]
+
+	for _, ck := range checks {
+		ck, ckGroups := v.cutGroups(ck)
+		if len(ckGroups) > 0 && !slices.ContainsFunc(ckGroups, func(g string) bool { return slices.Contains(groups, g) }) {
+			continue
+		}
+
+		// Escape back the separators splitChecks unescaped (unless quoted).
+		if !strings.Contains(ck, "'") {
+			ck = strings.ReplaceAll(ck, v.CheckSep, `\`+v.CheckSep)
+		}
+
+		kept = append(kept, ck)
+	}
+
+	return strings.Join(kept, v.CheckSep)
+}
+
+// cutGroups cuts the groups suffix (i.e. "@create@import") off check, if any.
+// Only the suffixes that look like group names (letters, digits, _ and -)
+// are cut, so that i.e. `regex:^[^@]+@[^@]+$` is left alone.
+func (v *Validator) cutGroups(check string) (rest string, groups []string) {
+	rest = strings.TrimSpace(check)
+	if v.GroupSep == "" {
+		return
+	}
+
+	for {
+		i := strings.LastIndex(rest, v.GroupSep)
+		if i <= 0 || !isGroupName(rest[i+len(v.GroupSep):]) {
+			return
+		}
+
+		groups = append(groups, rest[i+len(v.GroupSep):])
+		rest = rest[:i]
+	}
+}
+
+// isGroupName reports whether s is a valid group name.
+func isGroupName(s string) bool {
+	return s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-'
+	}) < 0
+}
+
+// joinChecks joins the (non empty) tags a and b.
+func (v *Validator) joinChecks(a, b string) string {
+	switch {
+	case a == "":
+		return b
+	case b == "":
+		return a
+	default:
+		return a + v.CheckSep + b
+	}
+}
+
+// validateNilStruct validates a nil pointer to a struct of type t, as per
+// the nil_struct modifier in tag or, lacking that, [Validator.NilStructs].
+func (v *Validator) validateNilStruct(st *state, t reflect.Type, tag string, scope ...string) (err error) {
+	_, chkNames, _ := v.parse(tag) // Already validated.
+	if slices.Contains(chkNames, "omitnil") || slices.Contains(chkNames, "omitempty") {
+		return
+	}
+
+	policy := v.NilStructs
+
+	for _, name := range chkNames {
+		if name, args, _ := strings.Cut(name, v.CheckArgSep); name == "nil_struct" {
+			policy = nilStructPolicies[unquote(args)]
+		}
+	}
+
+	switch policy {
+	case NilStructZero:
+		return v.validate(st, reflect.Zero(indirectType(t)), "", scope...)
+	case NilStructError:
+		return v.collect(st, newFieldError(ErrAbsent, "nil_struct", v.message(chkNames), st.errorPath(scope), st.jsonPath))
+	default:
+		return
+	}
+}
+
+// nilStructType returns the type of val, if a nil pointer to a (validated)
+// struct, including when held by an interface (i.e. a typed nil in an any).
+func nilStructType(val reflect.Value) reflect.Type {
+	for (val.Kind() == reflect.Pointer || val.Kind() == reflect.Interface) && !val.IsNil() {
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Pointer {
+		return nil
+	}
+
+	if t := indirectType(val.Type()); t.Kind() == reflect.Struct && !opaqueTypes[t] && !isSQLNull(t) {
+		return t
+	}
+
+	return nil
+}
+
+// validateElems validates (the struct tags of) each element of a slice,
+// array or map, with the index (or key) appended to the last scope entry.
+// Map keys are visited in a sorted order, so that errors are deterministic.
+func (v *Validator) validateElems(st *state, val reflect.Value, scope ...string) (err error) {
+	if val.Kind() == reflect.Map {
+		keys := val.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+		})
+
+		for _, k := range keys {
+			leave := st.enter(fmt.Sprint(k.Interface()))
+			err = v.validate(st, val.MapIndex(k), "", indexScope(scope, k.Interface())...)
+
+			if leave(); err != nil {
+				return
+			}
+		}
+
+		return
+	}
+
+	for i := range val.Len() {
+		leave := st.enter(strconv.Itoa(i))
+		err = v.validate(st, val.Index(i), "", indexScope(scope, i)...)
+
+		if leave(); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// hasStructElems reports whether val is a slice, array or map of
+// (pointers to) structs that are not validated as values.
+func hasStructElems(val reflect.Value) bool {
+	switch val.Kind() { //nolint:exhaustive // only collections have elements
+	case reflect.Slice, reflect.Array, reflect.Map:
+		t := indirectType(val.Type().Elem())
+		return t.Kind() == reflect.Struct && !opaqueTypes[t] && !isSQLNull(t)
+	default:
+		return false
+	}
+}
+
+// indexScope returns a copy of scope, with "[idx]" appended to its last entry
+// (or as its only entry, for the top level collections). The string (kinded)
+// idx are quoted, i.e. `["owner"]`, so that they can't pass for indexes.
+func indexScope(scope []string, idx any) []string {
+	elem := fmt.Sprintf("[%v]", idx)
+	if reflect.ValueOf(idx).Kind() == reflect.String {
+		elem = fmt.Sprintf("[%q]", idx)
+	}
+
+	if len(scope) == 0 {
+		return []string{elem}
+	}
+
+	scope = slices.Clone(scope)
+	scope[len(scope)-1] += elem
+
+	return scope
+}
+
+// validateScalar runs the tag checks against val, which was a non nil pointer
+// if supplied (see [Validator.RequiredPointers]). It reports whether val was
+// omitted, as per the omitempty/omitnil modifiers.
+func (v *Validator) validateScalar(st *state, val reflect.Value, tag string, supplied bool, scope ...string,
+) (omitted bool, err error) {
+	checks, chkNames, err := v.parse(tag)
+	if err != nil {
+		if path := st.errorPath(scope); len(path) > 0 {
+			err = fmt.Errorf("%s: %w", strings.Join(path, v.PathSep), err)
+		}
+
+		return
+	}
+
+	omitEmpty, omitNil := slices.Contains(chkNames, "omitempty"), slices.Contains(chkNames, "omitnil")
+	if (omitEmpty && isZero(val)) || (omitNil && isNil(val)) {
+		v.trace(st, scope, tag, "omitted", 0, nil)
+		return true, nil
+	}
+
+	explicit := omitEmpty || omitNil || v.ExplicitOmitEmpty
+	present, known := st.isPresent(scope)
+	msg := v.message(chkNames)
+	checks, chkNames = v.checkOrder(checks, chkNames)
+
+	for i, ck := range checks {
+		name, _, _ := strings.Cut(chkNames[i], v.CheckArgSep)
+
+		// When presence is known, required means present and absent
+		// values are not checked any further. Partial updates don't
+		// require anything to be present, but check what is, as usual.
+		if known {
+			if name == "required" && !present && !st.partial {
+				v.trace(st, scope, name, "failed", 0, ErrAbsent)
+				return false, newFieldError(ErrAbsent, name, msg, st.errorPath(scope), st.jsonPath)
+			}
+
+			if !present || (name == "required" && !st.partial) {
+				v.trace(st, scope, name, "skipped absent", 0, nil)
+				continue
+			}
+		}
+
+		// A non nil pointer is all that is required (see [Validator.RequiredPointers]).
+		if name == "required" && supplied {
+			v.trace(st, scope, name, "passed non nil", 0, nil)
+			continue
+		}
+
+		if !explicit && isZero(val) && !v.dontSkipZero(name) {
+			v.trace(st, scope, name, "skipped zero", 0, nil)
+			continue
+		}
 
-			if cm == nil {
-				return nil, nil, fmt.Errorf("%w %s", ErrInvalidChecker, tag)
+		target := val
+		if info, _ := v.DescribeChecker(strings.TrimLeft(name, "!")); info.Parent {
+			target = reflect.ValueOf(Field{Value: val, Parent: st.enclosing(val)})
+		}
+
+		if st.ctx != nil {
+			if err = st.ctx.Err(); err != nil {
+				return
 			}
 
-			c, err2 := cm(tagz[1])
-			if err2 != nil {
-				return nil, nil, fmt.Errorf("%w %s: %w", ErrInvalidChecker, tag, err2)
+			if cc, _ := lookup(v, name, func(v *Validator) map[string]ContextChecker { return v.ctxCheckers }); cc != nil {
+				ck = func(val reflect.Value) error { return cc(st.ctx, val) }
 			}
+		}
+
+		start := time.Now()
+		if err = ck(target); err != nil {
+			v.trace(st, scope, name, "failed", time.Since(start), err)
+			return false, newFieldError(err, name, msg, st.errorPath(scope), st.jsonPath)
+		}
+
+		v.trace(st, scope, name, "passed", time.Since(start), nil)
+	}
 
-			v.RegisterChecker(tag, c)
-			cx = append(cx, c)
-			cxNames = append(cxNames, tagz[0])
+	return
+}
+
+// trace logs the outcome of the check on the field at scope, via [Validator.Logger],
+// and reports it, if requested (see [Validator.Report]).
+func (v *Validator) trace(st *state, scope []string, check, outcome string, dur time.Duration, err error) {
+	if st.report != nil {
+		o := CheckOutcome{Field: strings.Join(st.errorPath(scope), v.PathSep), Check: check, Outcome: outcome, Duration: dur}
+		if err != nil {
+			o.Error = err.Error()
+		}
+
+		*st.report = append(*st.report, o)
+	}
+
+	if v.Logger == nil || !v.Logger.Enabled(st.ctx, slog.LevelDebug) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("field", strings.Join(scope, v.PathSep)),
+		slog.String("check", check),
+		slog.String("outcome", outcome),
+	}
+
+	if dur > 0 {
+		attrs = append(attrs, slog.Duration("duration", dur))
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	// The ctx is nil outside of [Validator.ValidateContext], which slog allows.
+	v.Logger.LogAttrs(st.ctx, slog.LevelDebug, "vali check", attrs...)
+}
+
+// message returns the custom message set via the `msg:'...'` modifier, if any.
+func (v *Validator) message(chkNames []string) string {
+	for _, name := range chkNames {
+		if name, args, _ := strings.Cut(name, v.CheckArgSep); name == "msg" {
+			return unquote(args)
+		}
+	}
+
+	return ""
+}
+
+// dontSkipZero reports whether the check (or any of
+// its alternatives) must run against zero values.
+func (v *Validator) dontSkipZero(name string) bool {
+	if v.OrSep == "" {
+		return slices.Contains(v.DontSkipZeroChecks, name)
+	}
+
+	for alt := range strings.SplitSeq(name, v.OrSep) {
+		if slices.Contains(v.DontSkipZeroChecks, alt) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// indirect fast-forwards through pointers, interfaces, the database/sql
+// nullable types (sql.NullString, sql.Null[T], etc.) and the types having
+// an [Extractor] to the underlying value.
+// Nil pointers and interfaces (including interfaces holding a nil pointer)
+// and invalid (null) values result in the zero [reflect.Value].
+func indirect(val reflect.Value) reflect.Value {
+	for {
+		if x, ok := extract(val); ok {
+			val = x
+			continue
+		}
+
+		switch {
+		case val.Kind() == reflect.Pointer, val.Kind() == reflect.Interface:
+			val = val.Elem()
+		case val.Kind() == reflect.Struct && isSQLNull(val.Type()):
+			if !val.FieldByName("Valid").Bool() {
+				return reflect.Value{}
+			}
+
+			val = val.Field(0)
 		default:
-			return nil, nil, fmt.Errorf("%w %s", ErrInvalidChecker, tag)
+			return val
+		}
+	}
+}
+
+// extract returns the value wrapped by val, if it has an [Extractor] (which
+// returns something else than val itself, so that it can't loop forever).
+func extract(val reflect.Value) (x reflect.Value, ok bool) {
+	if !val.IsValid() {
+		return
+	}
+
+	fn, ok := extractors.Load(val.Type())
+	if !ok {
+		return
+	}
+
+	x = reflect.ValueOf(fn.(Extractor)(val)) //nolint:forcetypeassert // only Extractors are stored
+
+	return x, !x.IsValid() || x.Type() != val.Type()
+}
+
+func isSQLNull(t reflect.Type) bool {
+	return t.PkgPath() == "database/sql" && strings.HasPrefix(t.Name(), "Null")
+}
+
+// String returns the value being checked, as a string (see [String]).
+func (f Field) String() string {
+	return String(f.Value)
+}
+
+// enclosing returns the struct enclosing val or, at
+// the top level (where there is none), val itself.
+func (st *state) enclosing(val reflect.Value) reflect.Value {
+	if st.parent.IsValid() {
+		return st.parent
+	}
+
+	return val
+}
+
+// errorPath returns the path to report in the errors for scope,
+// prefixed by the root type name, if any.
+func (st *state) errorPath(scope []string) []string {
+	if st.root == "" {
+		return scope
+	}
+
+	return append([]string{st.root}, scope...)
+}
+
+// enter appends the JSON path segment of a field (or element) to st, unless
+// empty (i.e. promoted fields), returning the func restoring it.
+func (st *state) enter(segment string) (leave func()) {
+	n := len(st.jsonPath)
+	if segment != "" {
+		st.jsonPath = append(st.jsonPath, segment)
+	}
+
+	return func() { st.jsonPath = st.jsonPath[:n] }
+}
+
+// collect records err in st, if it is a check failure and v collects them
+// (see [Validator.MaxErrors]), returning nil so that the validation goes on.
+// Once the limit is reached, it returns the truncation error instead. The
+// check failures are rendered by v.ErrorFormatter, if set.
+func (v *Validator) collect(st *state, err error) error {
+	var fe *FieldError
+	if !errors.As(err, &fe) {
+		return err
+	}
+
+	fe.format, fe.sep, fe.detail, fe.Locale = v.ErrorFormatter, v.PathSep, v.RequiredDetail, st.locale
+
+	limit := v.MaxErrors
+	if st.failFast {
+		limit = 0
+	} else if st.maxErrors != 0 {
+		limit = st.maxErrors
+	}
+
+	if limit == 0 {
+		return err
+	}
+
+	if limit > 0 && len(st.errs) == limit {
+		return fmt.Errorf("%w, stopped after %d", ErrTooManyErrors, limit)
+	}
+
+	st.errs = append(st.errs, fe)
+
+	return nil
+}
+
+// collected returns the [Errors] collected in st, if any, joined with err.
+func (st *state) collected(err error) error {
+	switch {
+	case len(st.errs) == 0:
+		return err
+	case err == nil:
+		return st.errs
+	default:
+		return errors.Join(st.errs, err)
+	}
+}
+
+// isPresent reports whether the field at the given path was present
+// in the input and whether that is known at all.
+func (st *state) isPresent(scope []string) (present, known bool) {
+	if st == nil || st.present == nil || len(scope) == 0 {
+		return
+	}
+
+	if !st.partial {
+		return st.present[strings.Join(scope, st.sep)], true
+	}
+
+	// The fields and elements of the present ones are present too.
+	for ; len(scope) > 0; scope = scope[:len(scope)-1] {
+		path := strings.Join(scope, st.sep)
+
+		for {
+			if st.present[path] {
+				return true, true
+			}
+
+			i := strings.LastIndex(path, "[")
+			if i <= 0 {
+				break
+			}
+
+			path = path[:i]
+		}
+	}
+
+	return false, true
+}
+
+// markPresent records the field (or element) at path as present in the input.
+func (st *state) markPresent(path []string) {
+	st.present[strings.Join(path, st.sep)] = true
+}
+
+func (v *Validator) parse(tag string) (cx []Checker, cxNames []string, err error) {
+	tags, err := v.splitChecks(tag)
+	if err != nil {
+		return
+	}
+
+	for _, tag := range tags {
+		if tag, _ = v.cutGroups(tag); tag == "" {
+			continue
+		}
+
+		var (
+			ck   Checker
+			name string
+		)
+
+		if v.CaseInsensitive {
+			tag = v.foldNames(tag)
+		}
+
+		if alts := v.alternatives(tag); len(alts) > 1 {
+			ck, name, err = v.parseAlternatives(tag, alts)
+		} else {
+			ck, name, err = v.parseCheck(tag)
+		}
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		cx = append(cx, ck)
+		cxNames = append(cxNames, name)
+	}
+
+	return
+}
+
+// splitChecks splits tag by [Validator.CheckSep]. Separators can be part of
+// the arguments of a check either by escaping them with a backslash, i.e.
+// `regex:^\d{1\,3}$`, or by single quoting the arguments: `regex:'^\d{1,3}$'`.
+// The quoted arguments end at the first quote followed by a separator (or
+// by the end of tag) and are passed to the checker maker without the quotes.
+func (v *Validator) splitChecks(tag string) (checks []string, err error) {
+	if v.CheckSep == "" {
+		return []string{tag}, nil
+	}
+
+	var (
+		b      strings.Builder
+		quoted bool
+	)
+
+	for i := 0; i < len(tag); {
+		rest := tag[i:]
+
+		switch {
+		case quoted:
+			quoted = rest[0] != '\'' || (len(rest) > 1 && !strings.HasPrefix(rest[1:], v.CheckSep))
+		case rest[0] == '\'' && strings.HasSuffix(b.String(), v.CheckArgSep):
+			quoted = true
+		case strings.HasPrefix(rest, `\`+v.CheckSep):
+			b.WriteString(v.CheckSep)
+			i += 1 + len(v.CheckSep)
+
+			continue
+		case strings.HasPrefix(rest, v.CheckSep):
+			checks = append(checks, b.String())
+			b.Reset()
+			i += len(v.CheckSep)
+
+			continue
+		}
+
+		b.WriteByte(tag[i])
+		i++
+	}
+
+	if quoted {
+		return nil, fmt.Errorf("%w %s: unterminated quote", ErrInvalidChecker, b.String())
+	}
+
+	return append(checks, b.String()), nil
+}
+
+// unquote removes the single quotes around args, if any.
+func unquote(args string) string {
+	if len(args) > 1 && args[0] == '\'' && args[len(args)-1] == '\'' {
+		return args[1 : len(args)-1]
+	}
+
+	return args
+}
+
+// foldNames replaces the checker names in tag (the part before the arguments,
+// which may hold alternatives and negations) with the registered ones they
+// match case insensitively. The arguments of `not` are checker names, too.
+func (v *Validator) foldNames(tag string) string {
+	names, args, hasArgs := strings.Cut(tag, v.CheckArgSep)
+
+	parts := []string{names}
+	if v.OrSep != "" {
+		parts = strings.Split(names, v.OrSep)
+	}
+
+	for i, part := range parts {
+		name := strings.TrimLeft(strings.TrimSpace(part), "!")
+		parts[i] = strings.Replace(part, name, v.foldName(name, hasArgs && i == len(parts)-1), 1)
+	}
+
+	if tag = strings.Join(parts, v.OrSep); !hasArgs {
+		return tag
+	}
+
+	if name := parts[len(parts)-1]; strings.TrimLeft(name, "!") == "not" {
+		args = v.foldNames(args)
+	}
+
+	return tag + v.CheckArgSep + args
+}
+
+// foldName returns the registered checker (or checker maker) name
+// matching name case insensitively, or name itself if there is none.
+func (v *Validator) foldName(name string, maker bool) string {
+	has := func(name string) (ok bool) {
+		if maker {
+			_, ok = lookup(v, name, func(v *Validator) map[string]CheckerMaker { return v.checkerMakers })
+		} else {
+			_, ok = lookup(v, name, func(v *Validator) map[string]Checker { return v.checkers })
+		}
+
+		return
+	}
+
+	if has(name) {
+		return name
+	}
+
+	if lower := strings.ToLower(name); has(lower) {
+		return lower
+	}
+
+	for p := v; p != nil; p = p.parent {
+		if other, ok := p.foldOwnName(name, maker); ok {
+			return other
+		}
+	}
+
+	return name
+}
+
+// foldOwnName returns the name registered to v (not to its parents)
+// matching name case insensitively, if any.
+func (v *Validator) foldOwnName(name string, maker bool) (other string, ok bool) {
+	defer v.rlock()()
+
+	names := maps.Keys(v.checkers)
+	if maker {
+		names = maps.Keys(v.checkerMakers)
+	}
+
+	for other = range names {
+		if strings.EqualFold(other, name) {
+			return other, true
+		}
+	}
+
+	return "", false
+}
+
+// parseCheck resolves a single check, either a [Checker] or a [CheckerMaker]
+// call (the result of which is cached as a checker, under the full tag).
+func (v *Validator) parseCheck(tag string) (ck Checker, name string, err error) {
+	if ck = v.checker(tag); ck != nil {
+		return ck, tag, nil
+	}
+
+	// Negations are unwrapped iteratively, so that long chains of them
+	// (i.e. `!!!!alpha` or `not:not:alpha`) can't blow the stack.
+	if inner, bangs, negated := v.negations(tag); inner != tag {
+		nots := tag[bangs:] != inner
+
+		if ck, name, err = v.parseCheck(inner); err != nil {
+			if nots {
+				err = fmt.Errorf("%w %s: %w", ErrInvalidChecker, tag[bangs:], err)
+			}
+
+			return nil, "", err
+		}
+
+		if negated {
+			ck = negate(ck, inner)
+		}
+
+		if name = tag[:bangs] + name; nots {
+			name = tag
+		}
+
+		v.cacheChecker(tag, ck)
+
+		return
+	}
+
+	name, args, ok := strings.Cut(tag, v.CheckArgSep)
+	if !ok || name == "" || args == "" {
+		return nil, "", v.invalidChecker(tag, name, nil)
+	}
+
+	cm, _ := lookup(v, name, func(v *Validator) map[string]CheckerMaker { return v.checkerMakers })
+	if cm == nil {
+		return nil, "", v.invalidChecker(tag, name, nil)
+	}
+
+	if ck, err = cm(unquote(args)); err != nil {
+		return nil, "", v.invalidChecker(tag, name, err)
+	}
+
+	v.cacheChecker(tag, ck)
+
+	return ck, tag, nil
+}
+
+// invalidChecker returns the [ErrInvalidChecker] error for tag, calling the
+// checker (maker) name, which tells the expected args of name, when missing,
+// or the closest registered name, when there's no such checker.
+func (v *Validator) invalidChecker(tag, name string, cause error) (err error) {
+	if cause != nil {
+		return fmt.Errorf("%w %s: %w", ErrInvalidChecker, tag, cause)
+	}
+
+	err = fmt.Errorf("%w %s", ErrInvalidChecker, tag)
+
+	info, ok := v.DescribeChecker(name)
+	if !ok {
+		return withHint(err, name, v.checkerNames())
+	}
+
+	if info.Args != "" {
+		err = fmt.Errorf("%w, expecting %s%s%s", err, name, v.CheckArgSep, info.Args)
+	}
+
+	return
+}
+
+// CheckTag checks tag against the [DefaultValidator].
+// See [Validator.CheckTag] for details.
+func CheckTag(tag string) error {
+	return DefaultValidator.CheckTag(tag)
+}
+
+// CheckTag reports whether tag is valid, without validating anything:
+// all its checks must be registered and their arguments well formed
+// (i.e. regexes must compile). The error wraps [ErrInvalidChecker].
+func (v *Validator) CheckTag(tag string) (err error) {
+	_, _, err = v.parse(tag)
+	return
+}
+
+// CheckStruct checks the tags of t against the [DefaultValidator].
+// See [Validator.CheckStruct] for details.
+func CheckStruct(t reflect.Type) error {
+	return DefaultValidator.CheckStruct(t)
+}
+
+// CheckStruct walks the struct type t (including its nested structs and
+// the collections of them) and checks all the tags found along the way (as
+// well as the external rules registered for it) via [Validator.CheckTag],
+// without needing a value. Call it at startup, to fail fast on invalid tags.
+// The errors (one per invalid tag) are prefixed by the field path.
+func (v *Validator) CheckStruct(t reflect.Type) error {
+	return v.walkTags(t, func(_ string, _ reflect.StructField, tag string) error {
+		return v.CheckTag(tag)
+	})
+}
+
+// walkTags calls visit for each (non empty) tag of the struct type t, including
+// the ones of its nested structs and collections of them, in the order they are
+// validated (see the order modifier). The errors returned
+// by visit are prefixed with the field path (with `[]` marking the collections).
+func (v *Validator) walkTags(t reflect.Type, visit func(path string, f reflect.StructField, tag string) error) error {
+	return v.walkType(t, visit, map[reflect.Type]bool{})
+}
+
+func (v *Validator) walkType(t reflect.Type, visit func(string, reflect.StructField, string) error,
+	seen map[reflect.Type]bool, scope ...string,
+) (err error) {
+	t = indirectType(t)
+	if t.Kind() != reflect.Struct || opaqueTypes[t] || isSQLNull(t) || seen[t] {
+		return
+	}
+
+	// Only the types being walked are tracked, to break the cycles, as the
+	// same type can be reached on multiple paths (i.e. Billing and Shipping).
+	seen[t] = true
+	defer delete(seen, t)
+
+	rules, err := v.typeRules(t)
+	if err != nil {
+		return
+	}
+
+	var errs []error
+
+	idx := v.fieldOrder(t, rules)
+
+	for n := range t.NumField() {
+		i := n
+		if idx != nil {
+			i = idx[n]
+		}
+
+		f := t.Field(i)
+
+		tag := v.fieldTag(f, rules)
+		if tag == "-" {
+			continue
 		}
+
+		localScope := append(slices.Clone(scope), f.Name)
+
+		if tag != "" {
+			path := strings.Join(localScope, ".")
+			if err = visit(path, f, tag); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", path, err))
+			}
+		}
+
+		ft := indirectType(f.Type)
+		if k := ft.Kind(); k == reflect.Slice || k == reflect.Array || k == reflect.Map {
+			ft, localScope[len(localScope)-1] = indirectType(ft.Elem()), f.Name+"[]"
+		}
+
+		errs = append(errs, v.walkType(ft, visit, seen, localScope...))
+	}
+
+	return errors.Join(errs...)
+}
+
+// ParseArgs splits the arguments of a checker maker using the [DefaultValidator].
+// See [Validator.ParseArgs] for details.
+func ParseArgs(args string) []string {
+	return DefaultValidator.ParseArgs(args)
+}
+
+// ParseArgs splits the arguments of a checker maker (i.e. "3|10" for
+// `between:3|10`) by [Validator.CheckArgListSep], trimming any spaces around
+// them. Custom checker makers should use it, rather than inventing their own
+// splitting, so that they work consistently with the builtin ones.
+func (v *Validator) ParseArgs(args string) (list []string) {
+	if v.CheckArgListSep == "" {
+		return []string{strings.TrimSpace(args)}
+	}
+
+	for arg := range strings.SplitSeq(args, v.CheckArgListSep) {
+		list = append(list, strings.TrimSpace(arg))
+	}
+
+	return
+}
+
+// ParseIntArg parses the integer argument of a checker maker (i.e. "3" for
+// `csv:3`), failing with an error wrapping [ErrInvalidArg] otherwise.
+func ParseIntArg(arg string) (n int, err error) {
+	if n, err = strconv.Atoi(strings.TrimSpace(arg)); err != nil {
+		return 0, fmt.Errorf("%w %q: not an integer", ErrInvalidArg, arg)
+	}
+
+	return
+}
+
+// ParseRangeArg parses a range argument using the [DefaultValidator].
+// See [Validator.ParseRangeArg] for details.
+func ParseRangeArg(args string) (lo, hi int, err error) {
+	return DefaultValidator.ParseRangeArg(args)
+}
+
+// ParseRangeArg parses the (inclusive) integer range argument of a checker
+// maker, i.e. "3|10" for `len_between:3|10`, failing with an error wrapping
+// [ErrInvalidArg] if it is not a pair of integers, or if lo > hi.
+func (v *Validator) ParseRangeArg(args string) (lo, hi int, err error) {
+	list := v.ParseArgs(args)
+	if len(list) != 2 { //nolint:mnd // lo and hi
+		return 0, 0, fmt.Errorf("%w %q: need 2 arguments, got %d", ErrInvalidArg, args, len(list))
+	}
+
+	if lo, err = ParseIntArg(list[0]); err != nil {
+		return 0, 0, err
+	}
+
+	if hi, err = ParseIntArg(list[1]); err != nil {
+		return 0, 0, err
+	}
+
+	if lo > hi {
+		return 0, 0, fmt.Errorf("%w %q: %d is greater than %d", ErrInvalidArg, args, lo, hi)
 	}
 
 	return
 }
 
+// ParseKVArgs parses key/value arguments using the [DefaultValidator].
+// See [Validator.ParseKVArgs] for details.
+func ParseKVArgs(args string) (map[string]string, error) {
+	return DefaultValidator.ParseKVArgs(args)
+}
+
+// ParseKVArgs parses the key=value arguments of a checker maker, i.e.
+// "min=3|max=10" for `password:min=3|max=10`, failing with an error wrapping
+// [ErrInvalidArg] for a pair without a key, or with a key given twice.
+// A key without a value (i.e. "strict") maps to "".
+func (v *Validator) ParseKVArgs(args string) (kv map[string]string, err error) {
+	kv = map[string]string{}
+
+	for _, arg := range v.ParseArgs(args) {
+		k, val, _ := strings.Cut(arg, "=")
+		if k = strings.TrimSpace(k); k == "" {
+			return nil, fmt.Errorf("%w %q: missing key", ErrInvalidArg, arg)
+		}
+
+		if _, ok := kv[k]; ok {
+			return nil, fmt.Errorf("%w %q: duplicate key", ErrInvalidArg, k)
+		}
+
+		kv[k] = strings.TrimSpace(val)
+	}
+
+	return
+}
+
+// not makes a checker that inverts the check passed as args, i.e. `not:alpha`,
+// which is equivalent to `!alpha`.
+func (v *Validator) not(args string) (ck Checker, err error) {
+	ck, _, err = v.parseCheck("!" + args)
+	return
+}
+
+// negations strips all the leading negations (`!` and `not:`) off tag, returning
+// the check left, the count of leading `!` and whether the negations count is odd.
+func (v *Validator) negations(tag string) (inner string, bangs int, negated bool) {
+	notPrefix := "not" + v.CheckArgSep
+
+	_, hasNot := lookup(v, "not", func(v *Validator) map[string]CheckerMaker { return v.checkerMakers })
+
+	for inner = tag; ; negated = !negated {
+		if rest, ok := strings.CutPrefix(inner, "!"); ok {
+			inner = rest
+		} else if rest, ok = strings.CutPrefix(inner, notPrefix); ok && hasNot && v.CheckArgSep != "" {
+			inner = unquote(rest)
+		} else {
+			break
+		}
+	}
+
+	return inner, len(tag) - len(strings.TrimLeft(tag, "!")), negated
+}
+
+// negate inverts the ck checker (of the tag check). Only the check
+// failures are inverted, the argument errors are returned as they are.
+func negate(ck Checker, check string) Checker {
+	return func(val reflect.Value) error {
+		if err := ck(val); err != nil {
+			if errors.Is(err, ErrInvalidArg) {
+				return err
+			}
+
+			return nil
+		}
+
+		return fmt.Errorf("%q must not pass %s", String(val), check)
+	}
+}
+
+// alternatives splits tag by [Validator.OrSep]. The first check taking
+// arguments swallows the rest of tag, as its arguments may contain OrSep.
+func (v *Validator) alternatives(tag string) (alts []string) {
+	if v.OrSep == "" {
+		return []string{tag}
+	}
+
+	if v.checker(tag) != nil {
+		return []string{tag}
+	}
+
+	for tag != "" {
+		alt, rest, found := strings.Cut(tag, v.OrSep)
+		if !found || strings.Contains(alt, v.CheckArgSep) {
+			return append(alts, strings.TrimSpace(tag))
+		}
+
+		alts, tag = append(alts, strings.TrimSpace(alt)), rest
+	}
+
+	return
+}
+
+// parseAlternatives builds a checker that passes if any of alts does.
+// Its name is the OrSep joined list of the alternatives names.
+func (v *Validator) parseAlternatives(tag string, alts []string) (ck Checker, name string, err error) {
+	cx, names := make([]Checker, len(alts)), make([]string, len(alts))
+
+	for i, alt := range alts {
+		if cx[i], names[i], err = v.parseCheck(alt); err != nil {
+			return
+		}
+
+		names[i], _, _ = strings.Cut(names[i], v.CheckArgSep)
+	}
+
+	ck = func(val reflect.Value) (err error) {
+		errs := make(altErrors, 0, len(cx))
+
+		for i, c := range cx {
+			if err = c(val); err == nil {
+				return
+			}
+
+			errs = append(errs, fmt.Errorf("%s: %w", names[i], err))
+		}
+
+		return errs
+	}
+
+	v.cacheChecker(tag, ck)
+
+	return ck, strings.Join(names, v.OrSep), nil
+}
+
 //nolint:gochecknoinits,gosmopolitan // we do want this one
 func init() {
 	// Force initialization of time.Local to avoid race in parallel tests.
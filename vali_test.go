@@ -1,13 +1,25 @@
 package vali
 
 import (
+	"bytes"
 	"cmp"
+	"context"
+	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"maps"
+	"net"
+	"net/netip"
+	"net/url"
 	"reflect"
 	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 type testCase struct { //nolint:govet // OK
@@ -41,6 +53,35 @@ func TestNew(t *testing.T) {
 	t.Skip("tested implicitly")
 }
 
+func TestNewTags(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		Name  string `binding:"required"`
+		Email string `binding:"required" validate:"email"`
+		Nick  string `binding:"required" validate:""`
+	}
+
+	v := New("validate", "binding")
+
+	if exp, act := "Name: required check failed: value missing", errString(v.Validate(user{})); act != exp {
+		t.Fatalf("Expected %q got %q", exp, act)
+	}
+
+	if err := v.Validate(user{Name: "bob"}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if exp, act := `Email: email check failed: "bob" is not a valid email address`,
+		errString(v.Validate(user{Name: "bob", Email: "bob"})); act != exp {
+		t.Fatalf("Expected %q got %q", exp, act)
+	}
+
+	if err := Validate(user{}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+}
+
 func TestRegisterChecker(t *testing.T) {
 	t.Parallel()
 
@@ -113,6 +154,56 @@ func TestRegisterCheckerMaker(t *testing.T) {
 	}
 }
 
+func TestRegisterParentChecker(t *testing.T) {
+	t.Parallel()
+
+	type span struct {
+		Lo int
+		Hi int `validate:"gt_field:Lo"`
+	}
+
+	v := New()
+	v.RegisterCheckerMaker("gt_field", func(args string) (Checker, error) {
+		return func(val reflect.Value) error {
+			f, ok := Interface(val).(Field)
+			if !ok {
+				return errors.New("no enclosing struct")
+			}
+
+			if lo := f.Parent.FieldByName(args).Int(); f.Value.Int() <= lo {
+				return fmt.Errorf("%d is not more than %s (%d)", f.Value.Int(), args, lo)
+			}
+
+			return nil
+		}, nil
+	}, CheckerInfo{Parent: true})
+
+	testCases := []struct {
+		val any
+		exp string
+	}{
+		{span{Lo: 1, Hi: 2}, ""},
+		{span{Lo: 2, Hi: 2}, "Hi: gt_field check failed: 2 is not more than Lo (2)"},
+		{&span{Lo: 3, Hi: 1}, "Hi: gt_field check failed: 1 is not more than Lo (3)"},
+	}
+
+	for _, tc := range testCases {
+		if act := errString(v.Validate(tc.val)); act != tc.exp {
+			t.Fatalf("Expected %q got %q for %v", tc.exp, act, tc.val)
+		}
+	}
+
+	type inverted struct {
+		Lo int
+		Hi int `validate:"!gt_field:Lo"`
+	}
+
+	exp := `Hi: !gt_field check failed: "2" must not pass gt_field:Lo`
+	if act := errString(v.Validate(inverted{Lo: 1, Hi: 2})); act != exp {
+		t.Fatalf("Expected %q got %q", exp, act)
+	}
+}
+
 func TestValidatorRegisterCheckerMaker(t *testing.T) {
 	t.Skip("tested implicitly")
 }
@@ -195,7 +286,7 @@ func TestValidate(t *testing.T) { //nolint:funlen // ok
 		{[]string{""}, nil, "eq:1", "", nil},
 		{map[int]string{0: ""}, nil, "eq:1", "", nil},
 
-		{0, nil, "min:foo", `min check failed: strconv.ParseInt: parsing "foo": invalid syntax`, ErrCheckFailed},
+		{0, nil, "min:foo", `invalid checker min:foo: invalid argument "foo": not a number or a time`, ErrInvalidChecker},
 		{0, nil, "min:5", "min check failed: 0 is less than 5", ErrCheckFailed},
 		{0, nil, "required,min:5", "required check failed: value missing", ErrCheckFailed},
 		{uint16(1), nil, "required,min:5", "min check failed: 1 is less than 5", ErrCheckFailed},
@@ -212,7 +303,7 @@ func TestValidate(t *testing.T) { //nolint:funlen // ok
 		{"abcde", nil, "min:5", "", nil},
 		{strings.Repeat("abcde", 1_000), nil, "min:5", "", nil},
 
-		{0, nil, "max:foo", `max check failed: strconv.ParseInt: parsing "foo": invalid syntax`, ErrCheckFailed},
+		{0, nil, "max:foo", `invalid checker max:foo: invalid argument "foo": not a number or a time`, ErrInvalidChecker},
 		{0, nil, "max:5", "", nil},
 		{int32(1000), nil, "max:5", "max check failed: 1000 is more than 5", ErrCheckFailed},
 		{uint64(6), nil, "max:5", "max check failed: 6 is more than 5", ErrCheckFailed},
@@ -244,11 +335,11 @@ func TestValidate(t *testing.T) { //nolint:funlen // ok
 		{[...]float64{1, 2, 3, 4, 5}, nil, "max:3", "max check failed: len 5 is more than 3", ErrCheckFailed},
 
 		{func() {}, nil, "min:2", "min check failed: len check failed: unsupported kind func", ErrCheckFailed},
-		{int(1), nil, "eq:foo", `eq check failed: strconv.ParseInt: parsing "foo": invalid syntax`, ErrCheckFailed},
-		{uint(1), nil, "ne:foo", `ne check failed: strconv.ParseUint: parsing "foo": invalid syntax`, ErrCheckFailed},
-		{float32(1), nil, "min:foo", `min check failed: strconv.ParseFloat: parsing "foo": invalid syntax`, ErrCheckFailed},
-		{float64(1), nil, "max:foo", `max check failed: strconv.ParseFloat: parsing "foo": invalid syntax`, ErrCheckFailed},
-		{"", nil, "ne:foo", `ne check failed: strconv.Atoi: parsing "foo": invalid syntax`, ErrCheckFailed},
+		{int(1), nil, "eq:foo", `invalid checker eq:foo: invalid argument "foo": not a number or a time`, ErrInvalidChecker},
+		{uint(1), nil, "ne:foo", `invalid checker ne:foo: invalid argument "foo": not a number or a time`, ErrInvalidChecker},
+		{float32(1), nil, "min:foo", `invalid checker min:foo: invalid argument "foo": not a number or a time`, ErrInvalidChecker},
+		{float64(1), nil, "max:foo", `invalid checker max:foo: invalid argument "foo": not a number or a time`, ErrInvalidChecker},
+		{"", nil, "ne:foo", `invalid checker ne:foo: invalid argument "foo": not a number or a time`, ErrInvalidChecker},
 
 		{struct {
 			Foo string
@@ -362,7 +453,7 @@ func TestValidate(t *testing.T) { //nolint:funlen // ok
 				Baz    string `json:",omitempty" validate:"one_of:foo|bar|baz"`
 				Foobar int
 			}{Foo: "foo", Bar: "Bar", Baz: "baz"},
-			nil, "", `Bar: one_of check failed: "Bar" does not match ^(foo|bar|baz)$`, ErrCheckFailed,
+			nil, "", `Bar: one_of check failed: "Bar" does not match ^(foo|bar|baz)$, did you mean "bar"?`, ErrCheckFailed,
 		},
 
 		// Private field validation tests.
@@ -519,6 +610,220 @@ func TestValidate(t *testing.T) { //nolint:funlen // ok
 	}
 }
 
+type (
+	stringerID struct{ n int }
+	textID     struct{ n int }
+	ptrTextID  struct{ n int }
+)
+
+func (id stringerID) String() string {
+	return fmt.Sprintf("usr_%d", id.n)
+}
+
+func (id textID) String() string {
+	return "ignored"
+}
+
+func (id textID) MarshalText() ([]byte, error) {
+	if id.n < 0 {
+		return nil, errors.New("negative id")
+	}
+
+	return fmt.Appendf(nil, "txt_%d", id.n), nil
+}
+
+func (id *ptrTextID) MarshalText() ([]byte, error) {
+	return fmt.Appendf(nil, "ptr_%d", id.n), nil
+}
+
+func TestString(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		val reflect.Value
+		exp string
+	}{
+		{val("foo"), "foo"},
+		{val(42), "42"},
+		{val(stringerID{1}), "usr_1"},
+		{val(textID{2}), "txt_2"},
+		{val(textID{-1}), "ignored"},
+		{reflect.ValueOf(&ptrTextID{3}).Elem(), "ptr_3"},
+		{val(ptrTextID{3}), "{3}"},
+		{val((*stringerID)(nil)), "<nil>"},
+		{val(struct{ id stringerID }{}).Field(0), "<nil>"},
+		{reflect.Value{}, "<nil>"},
+		{val([]byte("a@b.c")), "a@b.c"},
+		{val(json.RawMessage(`{"a":1}`)), `{"a":1}`},
+		{val(net.IPv4(1, 2, 3, 4)), "1.2.3.4"},
+	}
+
+	for _, tc := range testCases {
+		if act := String(tc.val); act != tc.exp {
+			t.Fatalf("Expected %q got %q", tc.exp, act)
+		}
+	}
+
+	s := struct {
+		ID  stringerID `validate:"regex:^usr_\\d+$"`
+		Txt *textID    `validate:"one_of:txt_1|txt_2"`
+	}{ID: stringerID{1}, Txt: &textID{3}}
+
+	if act := errString(Validate(s)); act != `Txt: one_of check failed: "txt_3" does not match ^(txt_1|txt_2)$, did you mean "txt_1"?` {
+		t.Fatalf("Unexpected error %q", act)
+	}
+
+	b := struct {
+		Email []byte `validate:"email,max:5"`
+	}{[]byte("a@b.co")}
+
+	if act := errString(Validate(b)); act != "Email: max check failed: len 6 is more than 5" {
+		t.Fatalf("Unexpected error %q", act)
+	}
+}
+
+func TestValidateSQLNull(t *testing.T) {
+	t.Parallel()
+
+	type row struct {
+		Name  sql.NullString   `validate:"required,min:3"`
+		Email sql.Null[string] `validate:"email"`
+		Age   *sql.NullInt64   `validate:"max:130"`
+		Score sql.NullFloat64  `validate:"min:0"`
+		When  sql.NullTime     `validate:"required"`
+		Inner sql.Null[struct {
+			A int `validate:"min:1"`
+		}]
+	}
+
+	valid := row{
+		Name:  sql.NullString{String: "Bob", Valid: true},
+		Email: sql.Null[string]{V: "bob@example.com", Valid: true},
+		Age:   &sql.NullInt64{Int64: 30, Valid: true},
+		When:  sql.NullTime{Time: time.Now(), Valid: true},
+	}
+
+	testCases := []struct {
+		mod func(*row)
+		exp string
+	}{
+		{func(*row) {}, ""},
+		{func(r *row) { r.Name.Valid = false }, "Name: required check failed: value missing"},
+		{func(r *row) { r.Name.String = "" }, "Name: required check failed: value missing"},
+		{func(r *row) { r.Name.String = "Al" }, "Name: min check failed: len 2 is less than 3"},
+		{func(r *row) { r.Email.V = "bob" }, `Email: email check failed: "bob" is not a valid email address`},
+		{func(r *row) { r.Email = sql.Null[string]{V: "bob"} }, ""},
+		{func(r *row) { r.Age.Int64 = 131 }, "Age: max check failed: 131 is more than 130"},
+		{func(r *row) { r.Age = nil }, ""},
+		{func(r *row) { r.Score = sql.NullFloat64{Float64: -1, Valid: true} }, "Score: min check failed: -1 is less than 0"},
+		{func(r *row) { r.When.Valid = false }, "When: required check failed: value missing"},
+		{func(r *row) { r.Inner.Valid = true }, "Inner.A: min check failed: 0 is less than 1"},
+	}
+
+	for _, tc := range testCases {
+		r := valid
+		r.Age = &sql.NullInt64{Int64: 30, Valid: true}
+		tc.mod(&r)
+
+		if act := errString(Validate(r)); act != tc.exp {
+			t.Fatalf("Expected %q got %q", tc.exp, act)
+		}
+	}
+
+	s, err := JSONSchema(row{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if typ := s.Properties["Name"].Type; typ != "string" {
+		t.Fatalf("Expected string got %q", typ)
+	}
+}
+
+func TestValidateInterface(t *testing.T) {
+	t.Parallel()
+
+	type (
+		inner struct {
+			A int `validate:"min:1"`
+		}
+
+		row struct {
+			Name  any          `validate:"required,min:3"`
+			Str   fmt.Stringer `validate:"required"`
+			Inner any
+		}
+	)
+
+	var nilTime *time.Time
+
+	testCases := []struct {
+		val row
+		exp string
+	}{
+		{row{Name: "Bob", Str: time.Second}, ""},
+		{row{Name: p("Bob"), Str: time.Second, Inner: &inner{A: 1}}, ""},
+		{row{Str: time.Second}, "Name: required check failed: value missing"},
+		{row{Name: (*string)(nil), Str: time.Second}, "Name: required check failed: value missing"},
+		{row{Name: "Al", Str: time.Second}, "Name: min check failed: len 2 is less than 3"},
+		{row{Name: 2, Str: time.Second}, "Name: min check failed: 2 is less than 3"},
+		{row{Name: "Bob", Str: nilTime}, "Str: required check failed: value missing"},
+		{row{Name: "Bob", Str: time.Second, Inner: inner{}}, "Inner.A: min check failed: 0 is less than 1"},
+	}
+
+	for _, tc := range testCases {
+		if act := errString(Validate(tc.val)); act != tc.exp {
+			t.Fatalf("Expected %q got %q", tc.exp, act)
+		}
+	}
+}
+
+func TestValidateElems(t *testing.T) {
+	t.Parallel()
+
+	type (
+		address struct {
+			City string `validate:"required"`
+		}
+
+		user struct {
+			Homes   []address `validate:"max:2"`
+			Ptrs    []*address
+			Fixed   [1]address
+			ByName  map[string]address
+			ByID    map[int]*address
+			Times   []time.Time
+			Nullish []sql.NullString
+		}
+	)
+
+	ok := address{City: "Paris"}
+	testCases := []struct {
+		val user
+		exp string
+	}{
+		{user{}, "Fixed[0].City: required check failed: value missing"},
+		{user{Fixed: [1]address{ok}, Homes: []address{ok, ok}, Ptrs: []*address{&ok, nil}}, ""},
+		{user{Fixed: [1]address{ok}, Homes: []address{ok, ok, ok}}, "Homes: max check failed: len 3 is more than 2"},
+		{user{Fixed: [1]address{ok}, Homes: []address{ok, {}}}, "Homes[1].City: required check failed: value missing"},
+		{user{Fixed: [1]address{ok}, Ptrs: []*address{&ok, {}}}, "Ptrs[1].City: required check failed: value missing"},
+		{user{Fixed: [1]address{ok}, ByName: map[string]address{"b": {}, "a": {}}}, `ByName["a"].City: required check failed: value missing`},
+		{user{Fixed: [1]address{ok}, ByID: map[int]*address{7: {}}}, "ByID[7].City: required check failed: value missing"},
+		{user{Fixed: [1]address{ok}, Times: []time.Time{{}}, Nullish: []sql.NullString{{}}}, ""},
+	}
+
+	for _, tc := range testCases {
+		if act := errString(Validate(tc.val)); act != tc.exp {
+			t.Fatalf("Expected %q got %q", tc.exp, act)
+		}
+	}
+
+	var fe *FieldError
+	if err := Validate(user{}); !errors.As(err, &fe) || fe.Field() != "Fixed[0].City" {
+		t.Fatalf("Unexpected field error %#v", fe)
+	}
+}
+
 func TestValidatorValidate(t *testing.T) {
 	t.Skip("tested implicitly")
 }
@@ -592,6 +897,1615 @@ func TestValidatorConfigurableSeparators(t *testing.T) {
 	}
 }
 
+func TestValidateAlternatives(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		val any
+		tag string
+		exp string
+	}{
+		{"10.0.0.1", "ipv4|ipv6", ""},
+		{"::1", "ipv4 | ipv6", ""},
+		{"foo", "ipv4|ipv6", `ipv4|ipv6 check failed: ipv4: "foo" is not a valid IPv4 address or ipv6: "foo" is not a valid IPv6 address`},
+		{"", "ipv4|ipv6", ""},
+		{"", "uuid|eq:0", ""},
+		{"abc", "uuid|eq:0", "uuid|eq check failed: uuid: \"abc\" does not match (?i)^[0-9a-f]{8}-?[0-9a-f]{4}-?[0-9a-f]{4}-?[0-9a-f]{4}-?[0-9a-f]{12}$ or eq: len 3 is not equal to 0"},
+		{"bar", "ipv4|one_of:foo|bar", ""},
+		{"baz", "ipv4|one_of:foo|bar", `ipv4|one_of check failed: ipv4: "baz" is not a valid IPv4 address or one_of: "baz" does not match ^(foo|bar)$, did you mean "bar"?`},
+		{"foo", "one_of:foo|bar", ""},
+		{"foo", "ipv4|bogus", "invalid checker bogus"},
+		{"foo", "ipv4|", `invalid checker ipv4|, did you mean "ipv4"?`},
+	}
+
+	for _, tc := range testCases {
+		for range 2 { // The second time, the checker is cached.
+			if act := errString(Validate(tc.val, tc.tag)); act != tc.exp {
+				t.Fatalf("Expected %q got %q for %q", tc.exp, act, tc.tag)
+			}
+		}
+	}
+
+	err := Validate("", "required|ipv4")
+	if !errors.Is(err, ErrRequired) || !errors.Is(err, ErrCheckFailed) {
+		t.Fatalf("Expected both %v and %v got %v", ErrRequired, ErrCheckFailed, err)
+	}
+
+	v := New()
+	v.OrSep = ""
+
+	if err = v.Validate("foo", "one_of:foo|bar,ipv4|ipv6"); !errors.Is(err, ErrInvalidChecker) {
+		t.Fatalf("Expected %v got %v", ErrInvalidChecker, err)
+	}
+}
+
+func TestValidateNegation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		val any
+		tag string
+		exp string
+	}{
+		{"abc1", "!alphanum", `!alphanum check failed: "abc1" must not pass alphanum`},
+		{"abc!", "!alphanum", ""},
+		{"abc!", "not:alphanum", ""},
+		{"Abc", "not:lowercase", ""},
+		{"abc", "not:lowercase", `not check failed: "abc" must not pass lowercase`},
+		{"admin", "!one_of:admin|root", `!one_of check failed: "admin" must not pass one_of:admin|root`},
+		{"bob", "!one_of:admin|root", ""},
+		{"bob", "not:one_of:admin|root", ""},
+		{"10.0.0.1", "!ipv4|!ipv6", ""},
+		{"", "!alpha", ""},
+		{"abc", "!bogus", "invalid checker bogus"},
+		{"abc", "not:bogus", "invalid checker not:bogus: invalid checker bogus"},
+		{"abc", "!!alpha", ""},
+		{"abc", "!!!alpha", `!!!alpha check failed: "abc" must not pass alpha`},
+		{"abc", "not:!not:alpha", `not check failed: "abc" must not pass alpha`},
+		{"abc", "!not:alpha", ""},
+		{"abc", "!", "invalid checker "},
+		{5, "!min:abc", `invalid checker min:abc: invalid argument "abc": not a number or a time`},
+		{5, "not:min:abc", `invalid checker not:min:abc: invalid checker min:abc: invalid argument "abc": not a number or a time`},
+		{5, "!max:1.5", `!max check failed: invalid argument "1.5": not an integer`},
+		{5, "!max:now", `!max check failed: invalid argument "now": not an integer`},
+	}
+
+	for _, tc := range testCases {
+		for range 2 { // The second time, the checker is cached.
+			if act := errString(Validate(tc.val, tc.tag)); act != tc.exp {
+				t.Fatalf("Expected %q got %q for %q", tc.exp, act, tc.tag)
+			}
+		}
+	}
+}
+
+type fakeMetrics []string
+
+func (m *fakeMetrics) Passed(typ string) {
+	*m = append(*m, "passed "+typ)
+}
+
+func (m *fakeMetrics) Failed(typ, field, check string) {
+	*m = append(*m, "failed "+typ+" "+field+" "+check)
+}
+
+func TestValidateMetrics(t *testing.T) {
+	t.Parallel()
+
+	type signup struct {
+		Email string `validate:"required,email"`
+		Age   int    `validate:"min:18"`
+	}
+
+	m := &fakeMetrics{}
+	v := New()
+	v.Metrics = m
+
+	_ = v.Validate(&signup{Email: "bob@example.com", Age: 21})
+	_ = v.Validate(signup{Email: "bob"})
+	_ = v.Validate((*signup)(nil))
+	_ = v.Validate("x", "bogus")
+	_ = v.ValidateJSON([]byte(`{"Age": 1}`), &signup{})
+	_ = v.ValidateValues(url.Values{}, map[string]string{"q": "required"})
+
+	v.MaxErrors = -1
+	_ = v.Validate(signup{Email: "bob", Age: 1})
+
+	exp := fakeMetrics{
+		"passed vali.signup",
+		"failed vali.signup Email email",
+		"passed vali.signup",
+		"failed vali.signup Email required",
+		"failed url.Values q required",
+		"failed vali.signup Email email",
+		"failed vali.signup Age min",
+	}
+
+	if !slices.Equal(*m, exp) {
+		t.Fatalf("Expected %q got %q", exp, *m)
+	}
+}
+
+func TestValidateLogger(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		Name  string `validate:"required,alpha"`
+		Email string `validate:"email"`
+		Age   int    `validate:"omitempty,min:18"`
+		Role  string `validate:"one_of:admin|user"`
+	}
+
+	var buf bytes.Buffer
+
+	v := New()
+	v.Logger = slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey || a.Key == "duration" {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+
+	err := v.Validate(user{Name: "bob", Role: "root"})
+	exp := `level=DEBUG msg="vali check" field=Name check=required outcome=passed
+level=DEBUG msg="vali check" field=Name check=alpha outcome=passed
+level=DEBUG msg="vali check" field=Email check=email outcome="skipped zero"
+level=DEBUG msg="vali check" field=Age check=omitempty,min:18 outcome=omitted
+level=DEBUG msg="vali check" field=Role check=one_of outcome=failed error="\"root\" does not match ^(admin|user)$"
+`
+
+	if act := buf.String(); act != exp || err == nil {
+		t.Fatalf("Expected:\n%s\ngot:\n%s", exp, act)
+	}
+
+	buf.Reset()
+	v.Logger = slog.New(slog.NewTextHandler(&buf, nil))
+
+	if _ = v.Validate(user{Name: "bob"}); buf.Len() > 0 {
+		t.Fatalf("Expected nothing logged above the debug level, got %s", buf.String())
+	}
+
+	h := &ctxHandler{Handler: slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})}
+	v.Logger = slog.New(h)
+	ctx := context.WithValue(t.Context(), ctxKey{}, "req-1")
+
+	if _ = v.ValidateContext(ctx, user{Name: "bob"}); len(h.vals) == 0 || h.vals[0] != "req-1" {
+		t.Fatalf("Expected the context to be logged with, got %v", h.vals)
+	}
+}
+
+type (
+	ctxKey struct{}
+
+	// ctxHandler records the ctxKey values of the contexts it logs with.
+	ctxHandler struct {
+		slog.Handler
+
+		vals []any
+	}
+)
+
+func (h *ctxHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.vals = append(h.vals, ctx.Value(ctxKey{}))
+
+	return h.Handler.Handle(ctx, r)
+}
+
+func TestValidateCaseInsensitive(t *testing.T) {
+	t.Parallel()
+
+	v := New()
+	v.CaseInsensitive = true
+	v.RegisterChecker("myCheck", func(reflect.Value) error { return errors.New("nope") })
+
+	testCases := []struct {
+		val any
+		tag string
+		exp string
+	}{
+		{_uuid, "Required,UUID", ""},
+		{"", "Required,UUID", "required check failed: value missing"},
+		{"abc", "Regex:^[a-c]+$", ""},
+		{"ABC", "REGEX:^[a-c]+$", `regex check failed: "ABC" does not match ^[a-c]+$`},
+		{"abc", "Min:5", "min check failed: len 3 is less than 5"},
+		{"10.0.0.1", "IPv6|IPv4", ""},
+		{"abc", "!Alpha", `!alpha check failed: "abc" must not pass alpha`},
+		{"abc", "Not:Alpha", `not check failed: "abc" must not pass alpha`},
+		{"1", "Alpha|One_Of:A|B", `alpha|one_of check failed: alpha: "1" does not match (?i)^[a-z]*$ or one_of: "1" does not match ^(A|B)$`},
+		{"", "OmitEmpty,Email", ""},
+		{"x", "MYCHECK", "myCheck check failed: nope"},
+		{"x", "mycheck", "myCheck check failed: nope"},
+		{"x", "Bogus", "invalid checker Bogus"},
+	}
+
+	for _, tc := range testCases {
+		if act := errString(v.Validate(tc.val, tc.tag)); act != tc.exp {
+			t.Fatalf("Expected %q got %q for %q", tc.exp, act, tc.tag)
+		}
+	}
+
+	if err := Validate("x", "Alpha"); !errors.Is(err, ErrInvalidChecker) {
+		t.Fatalf("Expected %v got %v", ErrInvalidChecker, err)
+	}
+}
+
+func TestFreeze(t *testing.T) {
+	t.Parallel()
+
+	v := New()
+	v.RegisterChecker("even", func(val reflect.Value) error {
+		if val.Int()%2 != 0 {
+			return errors.New("odd")
+		}
+
+		return nil
+	})
+	v.Freeze()
+	v.Freeze()
+
+	for _, register := range []func(){
+		func() { v.RegisterChecker("x", noop) },
+		func() { v.RegisterCheckerMaker("x", message) },
+		func() { v.RegisterContextChecker("x", nil) },
+		func() { v.LoadRules(Rules{"x": {}}) },
+	} {
+		func() {
+			defer func() {
+				if err, _ := recover().(error); !errors.Is(err, ErrFrozen) {
+					t.Fatalf("Expected %v got %v", ErrFrozen, err)
+				}
+			}()
+
+			register()
+		}()
+	}
+
+	var wg sync.WaitGroup
+
+	for i := range 8 {
+		wg.Go(func() {
+			for range 100 {
+				if err := v.Validate(2*i, "even,min:0,!eq:1,max:100"); err != nil {
+					t.Errorf("Unexpected error %v", err)
+				}
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if exp, act := "even check failed: odd", errString(v.Validate(3, "even,min:0")); act != exp {
+		t.Fatalf("Expected %q got %q", exp, act)
+	}
+}
+
+func TestConcurrentParse(t *testing.T) {
+	t.Parallel()
+
+	v := New()
+	v.RegisterChecker("even", func(val reflect.Value) error {
+		if val.Int()%2 != 0 {
+			return errors.New("odd")
+		}
+
+		return nil
+	})
+
+	var wg sync.WaitGroup
+
+	for i := range 8 {
+		wg.Go(func() {
+			for j := range 100 {
+				tag := fmt.Sprintf("min:%d,!eq:%d,even|eq:%d", i-8, j+1, j)
+
+				if err := v.Validate(j, tag); err != nil {
+					t.Errorf("Unexpected error %v", err)
+				}
+
+				exp := fmt.Sprintf(`!eq check failed: "%d" must not pass eq:%d`, j+1, j+1)
+				if act := errString(v.Validate(j+1, tag)); act != exp {
+					t.Errorf("Expected %q got %q", exp, act)
+				}
+
+				exp = fmt.Sprintf("even|eq check failed: even: odd or eq: %d is not equal to %d", 2*j+3, j)
+				if act := errString(v.Validate(2*j+3, tag)); act != exp {
+					t.Errorf("Expected %q got %q", exp, act)
+				}
+			}
+		})
+	}
+
+	wg.Go(func() {
+		for i := range 100 {
+			v.RegisterChecker(fmt.Sprintf("noop%d", i), noop)
+		}
+	})
+
+	wg.Wait()
+
+	for name := range v.Checkers() {
+		if strings.Contains(name, ":") {
+			t.Fatalf("Expected the parsed checkers to be kept out of the registry, got %q", name)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	type order struct {
+		SKU   string `json:"sku" valid:"sku"`
+		Qty   int    `json:"qty" valid:"multiple:6"`
+		Notes string `json:"notes"`
+	}
+
+	base := New("valid")
+	base.CheckSep = ";"
+	base.RegisterChecker("sku", func(val reflect.Value) error {
+		if !strings.HasPrefix(val.String(), "SKU-") {
+			return errors.New("bad sku")
+		}
+
+		return nil
+	})
+	base.RegisterCheckerMaker("multiple", func(args string) (Checker, error) {
+		n, err := strconv.Atoi(args)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(val reflect.Value) error {
+			if val.Int()%int64(n) != 0 {
+				return fmt.Errorf("not a multiple of %d", n)
+			}
+
+			return nil
+		}, nil
+	})
+	base.LoadRules(Rules{reflect.TypeFor[order]().String(): {"Notes": "max:3"}})
+
+	svc := NewFrom(base)
+	svc.RegisterChecker("even", func(val reflect.Value) error {
+		if val.Int()%2 != 0 {
+			return errors.New("odd")
+		}
+
+		return nil
+	})
+
+	if err := svc.Validate(order{SKU: "SKU-1", Qty: 12}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	for val, exp := range map[order]string{
+		{SKU: "X", Qty: 6}:                    "SKU: sku check failed: bad sku",
+		{SKU: "SKU-1", Qty: 5}:                "Qty: multiple check failed: not a multiple of 6",
+		{SKU: "SKU-1", Qty: 6, Notes: "long"}: "Notes: max check failed: len 4 is more than 3",
+	} {
+		if act := errString(svc.Validate(val)); act != exp {
+			t.Fatalf("Expected %q got %q", exp, act)
+		}
+	}
+
+	if exp, act := "", errString(svc.Validate(4, "even;not:sku;min:2")); act != exp {
+		t.Fatalf("Expected %q got %q", exp, act)
+	}
+
+	if err := base.Validate(4, "even"); !errors.Is(err, ErrInvalidChecker) {
+		t.Fatalf("Expected %v got %v", ErrInvalidChecker, err)
+	}
+
+	// Merging overrides, and makes the merged checkers custom (so they carry on).
+	base.RegisterChecker("sku", noop)
+	svc.Merge(base)
+	svc.Merge(svc)
+
+	if err := NewFrom(svc).Validate(order{SKU: "X", Qty: 6}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	svc.Freeze()
+
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, ErrFrozen) {
+			t.Fatalf("Expected %v got %v", ErrFrozen, err)
+		}
+	}()
+
+	svc.Merge(base)
+}
+
+func TestChild(t *testing.T) {
+	t.Parallel()
+
+	type account struct {
+		Plan string   `validate:"plan"`
+		Name string   `validate:"required"`
+		Tags []string `validate:"subset:a|b"`
+	}
+
+	plans := func(plans ...string) Checker {
+		return func(val reflect.Value) error {
+			if !slices.Contains(plans, val.String()) {
+				return errors.New("unknown plan")
+			}
+
+			return nil
+		}
+	}
+
+	shared := New()
+	shared.RegisterChecker("plan", plans("free", "pro"))
+
+	acme, globex := shared.Child(), shared.Child()
+	acme.RegisterChecker("plan", plans("free", "pro", "enterprise"))
+	acme.CheckArgListSep = ";"
+	globex.LoadRules(Rules{reflect.TypeFor[account]().String(): {"Name": "min:3"}})
+
+	testCases := []struct {
+		v   *Validator
+		val account
+		exp string
+	}{
+		{shared, account{Plan: "enterprise", Name: "x"}, "Plan: plan check failed: unknown plan"},
+		{acme, account{Plan: "enterprise", Name: "x"}, ""},
+		{acme, account{Plan: "gold", Name: "x"}, "Plan: plan check failed: unknown plan"},
+		{acme, account{Plan: "pro", Name: "x", Tags: []string{"a"}}, `Tags: subset check failed: "a" is not one of [a|b]`},
+		{globex, account{Plan: "enterprise", Name: "xyz"}, "Plan: plan check failed: unknown plan"},
+		{globex, account{Plan: "pro", Name: "x"}, "Name: min check failed: len 1 is less than 3"},
+		{globex, account{Plan: "pro", Name: "xyz", Tags: []string{"a"}}, ""},
+	}
+
+	for _, tc := range testCases {
+		if act := errString(tc.v.Validate(tc.val)); act != tc.exp {
+			t.Fatalf("Expected %q got %q for %+v", tc.exp, act, tc.val)
+		}
+	}
+
+	// Negations resolve against the child, and see the later parent registrations.
+	if exp, act := "!not check failed: unknown plan", errString(acme.Validate("gold", "!not:plan")); act != exp {
+		t.Fatalf("Expected %q got %q", exp, act)
+	}
+
+	if err := acme.Validate("enterprise", "!not:plan"); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if err := globex.Validate("x", "!gold"); !errors.Is(err, ErrInvalidChecker) {
+		t.Fatalf("Expected %v got %v", ErrInvalidChecker, err)
+	}
+
+	shared.RegisterChecker("gold", plans("gold"))
+
+	if err := globex.Validate("x", "!gold"); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if err := globex.Validate("gold", "!plan"); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	shared.RegisterChecker("plan", plans("gold")) // Invalidates the checkers cached by the children.
+
+	if exp, act := `!plan check failed: "gold" must not pass plan`, errString(globex.Validate("gold", "!plan")); act != exp {
+		t.Fatalf("Expected %q got %q", exp, act)
+	}
+
+	acme.CaseInsensitive = true
+
+	if err := acme.Validate("gold", "Gold"); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+}
+
+func TestValidateMaxErrors(t *testing.T) {
+	t.Parallel()
+
+	type (
+		item struct {
+			SKU string `validate:"required"`
+		}
+
+		order struct {
+			ID    string `validate:"min:3"`
+			Items []item
+			Notes string `validate:"max:3"`
+		}
+	)
+
+	val := order{ID: "x", Items: make([]item, 3), Notes: "long"}
+
+	testCases := []struct {
+		max int
+		exp string
+	}{
+		{0, "ID: min check failed: len 1 is less than 3"},
+		{-1, "ID: min check failed: len 1 is less than 3\nItems[0].SKU: required check failed: value missing\n" +
+			"Items[1].SKU: required check failed: value missing\nItems[2].SKU: required check failed: value missing\n" +
+			"Notes: max check failed: len 4 is more than 3"},
+		{5, "ID: min check failed: len 1 is less than 3\nItems[0].SKU: required check failed: value missing\n" +
+			"Items[1].SKU: required check failed: value missing\nItems[2].SKU: required check failed: value missing\n" +
+			"Notes: max check failed: len 4 is more than 3"},
+		{2, "ID: min check failed: len 1 is less than 3\nItems[0].SKU: required check failed: value missing\n" +
+			"too many errors, stopped after 2"},
+	}
+
+	for _, tc := range testCases {
+		v := New()
+		v.MaxErrors = tc.max
+
+		err := v.Validate(val)
+		if act := errString(err); act != tc.exp {
+			t.Fatalf("Expected %q got %q for %d", tc.exp, act, tc.max)
+		}
+
+		if !errors.Is(err, ErrCheckFailed) || errors.Is(err, ErrTooManyErrors) != (tc.max == 2) {
+			t.Fatalf("Unexpected error %v for %d", err, tc.max)
+		}
+	}
+
+	v := New()
+	v.MaxErrors = -1
+
+	if exp, act := "a: min check failed: len 1 is less than 2\nb: invalid checker bogus",
+		errString(v.ValidateValues(url.Values{"a": {"x"}}, map[string]string{"a": "min:2", "b": "bogus"})); act != exp {
+		t.Fatalf("Expected %q got %q", exp, act)
+	}
+}
+
+func TestValidateRootTypeName(t *testing.T) {
+	t.Parallel()
+
+	type (
+		address struct {
+			City string `validate:"required"`
+		}
+
+		createUserRequest struct {
+			Email   string `json:"email" validate:"required"`
+			Address address
+			Nick    string `validate:"bogus"`
+		}
+	)
+
+	v := New()
+	v.RootTypeName = true
+
+	testCases := []struct {
+		val any
+		exp string
+	}{
+		{createUserRequest{}, "createUserRequest.Email: required check failed: value missing"},
+		{&createUserRequest{Email: "x"}, "createUserRequest.Address.City: required check failed: value missing"},
+		{createUserRequest{Email: "x", Address: address{"y"}}, "createUserRequest.Nick: invalid checker bogus"},
+		{struct {
+			A string `validate:"required"`
+		}{}, "A: required check failed: value missing"},
+	}
+
+	for _, tc := range testCases {
+		if act := errString(v.Validate(tc.val)); act != tc.exp {
+			t.Fatalf("Expected %q got %q", tc.exp, act)
+		}
+	}
+
+	if exp, act := "required check failed: value missing", errString(v.Validate("", "required")); act != exp {
+		t.Fatalf("Expected %q got %q", exp, act)
+	}
+
+	var fe *FieldError
+	if err := v.ValidateJSON([]byte(`{}`), &createUserRequest{}); !errors.As(err, &fe) ||
+		fe.Field() != "createUserRequest.Email" || fe.JSONPath() != "/email" {
+		t.Fatalf("Unexpected error %v", err)
+	}
+}
+
+func TestValidateTopLevelCollections(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		Email string `json:"email" validate:"required"`
+	}
+
+	ok := user{"bob@example.com"}
+
+	testCases := []struct {
+		val  any
+		tag  string
+		exp  string
+		path string
+	}{
+		{[]user{ok, ok, ok, {}}, "", "[3].Email: required check failed: value missing", "/3/email"},
+		{&[]*user{&ok, nil}, "", "", ""},
+		{[2]user{ok}, "", "[1].Email: required check failed: value missing", "/1/email"},
+		{map[string]user{"b": {}, "a": ok}, "", `["b"].Email: required check failed: value missing`, "/b/email"},
+		{[]user{}, "min:1", "min check failed: len 0 is less than 1", ""},
+		{[]string{""}, "", "", ""},
+	}
+
+	for _, tc := range testCases {
+		err := Validate(tc.val, tc.tag)
+		if act := errString(err); act != tc.exp {
+			t.Fatalf("Expected %q got %q", tc.exp, act)
+		}
+
+		if fe := (*FieldError)(nil); errors.As(err, &fe) && fe.JSONPath() != tc.path {
+			t.Fatalf("Expected %q got %q", tc.path, fe.JSONPath())
+		}
+	}
+}
+
+func TestValidateNilStructs(t *testing.T) {
+	t.Parallel()
+
+	type (
+		address struct {
+			City string `json:"city" validate:"required"`
+		}
+
+		user struct {
+			Billing  *address `json:"billing"`
+			Shipping *address `json:"shipping" validate:"nil_struct:skip"`
+			Home     **address
+			Work     *address `validate:"omitnil"`
+			Time     *time.Time
+		}
+
+		strict struct {
+			Billing *address `validate:"nil_struct:error,msg:'billing, please'"`
+			Other   *address `validate:"nil_struct:zero"`
+		}
+	)
+
+	var home *address
+
+	testCases := []struct {
+		policy NilStructPolicy
+		val    any
+		exp    string
+	}{
+		{NilStructSkip, user{}, ""},
+		{NilStructZero, user{}, "Billing.City: required check failed: value missing"},
+		{NilStructZero, user{Billing: &address{"x"}, Home: p(&address{"x"})}, ""},
+		{NilStructZero, user{Billing: &address{"x"}, Home: &home}, "Home.City: required check failed: value missing"},
+		{NilStructError, user{}, "Billing: nil_struct check failed: value missing"},
+		{NilStructError, user{Billing: &address{}}, "Billing.City: required check failed: value missing"},
+		{NilStructSkip, strict{}, "Billing: nil_struct check failed: billing, please"},
+		{NilStructSkip, strict{Billing: &address{"x"}}, "Other.City: required check failed: value missing"},
+		{NilStructError, struct {
+			A *address `validate:"nil_struct:bogus"`
+		}{}, `A: invalid checker nil_struct:bogus: invalid nil struct policy "bogus"`},
+	}
+
+	for _, tc := range testCases {
+		v := New()
+		v.NilStructs = tc.policy
+
+		if act := errString(v.Validate(tc.val)); act != tc.exp {
+			t.Fatalf("Expected %q got %q for %d", tc.exp, act, tc.policy)
+		}
+	}
+
+	v := New()
+	v.NilStructs = NilStructError
+	v.MaxErrors = -1
+
+	var errs Errors
+	if err := v.Validate(user{}); !errors.As(err, &errs) || len(errs) != 2 || errs[1].JSONPath() != "/Home" {
+		t.Fatalf("Unexpected error %v", err)
+	}
+}
+
+func TestValidateRequiredPointers(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		Name  *string `json:"name"  validate:"required,max:3"`
+		Age   *int    `json:"age"   validate:"required,min:18"`
+		Email string  `json:"email" validate:"required"`
+	}
+
+	testCases := []struct {
+		val      user
+		pointers bool
+		exp      string
+	}{
+		{user{Name: p(""), Age: p(20), Email: "x"}, false, "Name: required check failed: value missing"},
+		{user{Name: p(""), Age: p(20), Email: "x"}, true, ""},
+		{user{Name: p(""), Age: p(0), Email: "x"}, true, "Age: min check failed: 0 is less than 18"},
+		{user{Age: p(20), Email: "x"}, true, "Name: required check failed: value missing"},
+		{user{Name: p("Bobby"), Age: p(20), Email: "x"}, true, "Name: max check failed: len 5 is more than 3"},
+		{user{Name: p(""), Age: p(20)}, true, "Email: required check failed: value missing"},
+	}
+
+	for _, tc := range testCases {
+		v := New()
+		v.RequiredPointers = tc.pointers
+
+		if act := errString(v.Validate(tc.val)); act != tc.exp {
+			t.Fatalf("Expected %q got %q for %+v", tc.exp, act, tc.val)
+		}
+	}
+
+	v := New()
+	v.RequiredPointers = true
+
+	var u user
+	if err := v.ValidateJSON([]byte(`{"name": "", "age": null, "email": "x"}`), &u); !errors.Is(err, ErrRequired) {
+		t.Fatalf("Expected %v got %v", ErrRequired, err)
+	}
+}
+
+func TestValidateRequiredDetail(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		Name *string  `json:"name" validate:"required"`
+		Tags []string `json:"tags" validate:"required"`
+	}
+
+	testCases := []struct {
+		val    user
+		detail bool
+		exp    string
+		expErr error
+	}{
+		{user{Tags: []string{"a"}}, false, "Name: required check failed: value missing", ErrAbsent},
+		{user{Tags: []string{"a"}}, true, "Name: required check failed: value missing (absent)", ErrAbsent},
+		{user{Name: p(""), Tags: []string{"a"}}, true, "Name: required check failed: value missing (empty)", ErrEmpty},
+		{user{Name: p("x")}, true, "Tags: required check failed: value missing (absent)", ErrAbsent},
+	}
+
+	for _, tc := range testCases {
+		v := New()
+		v.RequiredDetail = tc.detail
+
+		err := v.Validate(tc.val)
+		if act := errString(err); act != tc.exp || !errors.Is(err, tc.expErr) || !errors.Is(err, ErrRequired) {
+			t.Fatalf("Expected %q (%v) got %q", tc.exp, tc.expErr, act)
+		}
+	}
+
+	v := New()
+	v.RequiredDetail = true
+
+	var u user
+	if err := v.ValidateJSON([]byte(`{"name": ""}`), &u); !errors.Is(err, ErrAbsent) {
+		t.Fatalf("Expected the absent key to fail with %v got %v", ErrAbsent, err)
+	}
+
+	if err := v.Validate("", "required,msg:'name, please'"); errString(err) != "required check failed: name, please" {
+		t.Fatalf("Expected the custom message alone got %v", err)
+	}
+}
+
+func TestValidateTypedNil(t *testing.T) {
+	t.Parallel()
+
+	type (
+		impl struct {
+			Name string `validate:"required"`
+		}
+
+		holder struct {
+			F   any          `validate:"required"`
+			S   fmt.Stringer `validate:"omitnil,min:3"`
+			Err error        `validate:"omitnil,required"`
+			Ptr any          `validate:"nil_struct:error"`
+		}
+	)
+
+	var (
+		nilImpl *impl
+		nilErr  *FieldError
+	)
+
+	testCases := []struct {
+		val holder
+		exp string
+	}{
+		{holder{F: nilImpl, Ptr: &impl{"x"}}, "F: required check failed: value missing"},
+		{holder{F: &nilImpl, Ptr: &impl{"x"}}, "F: required check failed: value missing"},
+		{holder{F: 1, S: (*time.Location)(nil), Err: nilErr, Ptr: &impl{"x"}}, ""},
+		{holder{F: 1, Ptr: nilImpl}, "Ptr: nil_struct check failed: value missing"},
+		{holder{F: 1, Ptr: &impl{}}, "Ptr.Name: required check failed: value missing"},
+	}
+
+	for _, tc := range testCases {
+		err := Validate(tc.val)
+		if act := errString(err); act != tc.exp {
+			t.Fatalf("Expected %q got %q for %+v", tc.exp, act, tc.val)
+		}
+
+		if err != nil && !errors.Is(err, ErrRequired) {
+			t.Fatalf("Expected %v got %v", ErrRequired, err)
+		}
+	}
+}
+
+func TestValidatePromote(t *testing.T) {
+	t.Parallel()
+
+	type (
+		user struct {
+			Name string `validate:"required"`
+		}
+
+		audited struct {
+			CreatedBy string
+			UpdatedBy string `validate:"min:3"`
+			Owner     user
+			note      string //nolint:unused // unexported fields are not promoted to
+		}
+
+		doc struct {
+			audited `validate:"promote,required"`
+
+			Title string `validate:"required"`
+		}
+
+		plain struct {
+			audited `validate:"required"`
+		}
+	)
+
+	ok := audited{CreatedBy: "bob", UpdatedBy: "bob", Owner: user{"bob"}}
+
+	testCases := []struct {
+		val any
+		exp string
+	}{
+		{doc{audited: ok, Title: "x"}, ""},
+		{doc{Title: "x"}, "audited.CreatedBy: required check failed: value missing"},
+		{doc{audited: audited{CreatedBy: "bob"}, Title: "x"}, "audited.UpdatedBy: required check failed: value missing"},
+		{doc{audited: audited{CreatedBy: "bob", UpdatedBy: "al"}}, "audited.UpdatedBy: min check failed: len 2 is less than 3"},
+		{doc{audited: audited{CreatedBy: "bob", UpdatedBy: "bob", Owner: user{"x"}}}, "Title: required check failed: value missing"},
+		{doc{audited: audited{CreatedBy: "bob", UpdatedBy: "bob", Owner: user{}}}, "audited.Owner: required check failed: value missing"},
+		{plain{}, "audited: required check failed: value missing"},
+		{plain{ok}, ""},
+	}
+
+	for _, tc := range testCases {
+		if act := errString(Validate(tc.val)); act != tc.exp {
+			t.Fatalf("Expected %q got %q", tc.exp, act)
+		}
+	}
+}
+
+func TestValidateSkipMarker(t *testing.T) {
+	t.Parallel()
+
+	type (
+		address struct {
+			City string `validate:"required"`
+		}
+
+		audited struct {
+			CreatedBy string
+			internal  address  `validate:"-"` //nolint:unused // the promoted checks skip it
+			Legacy    *address `validate:"-"`
+		}
+
+		doc struct {
+			audited `validate:"promote,required"`
+
+			Home address
+		}
+	)
+
+	v := New()
+	v.NilStructs = NilStructError
+	v.LoadRules(Rules{reflect.TypeFor[doc]().String(): {"Home": "-"}})
+
+	if err := v.Validate(doc{audited: audited{CreatedBy: "bob"}}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if exp, act := "audited.CreatedBy: required check failed: value missing", errString(v.Validate(doc{})); act != exp {
+		t.Fatalf("Expected %q got %q", exp, act)
+	}
+}
+
+func TestValidateValue(t *testing.T) {
+	t.Parallel()
+
+	type (
+		user struct {
+			Email string `validate:"required,email"`
+		}
+
+		row struct {
+			Owner user
+			name  string
+		}
+	)
+
+	r := reflect.ValueOf(row{name: "x"})
+
+	testCases := []struct {
+		val reflect.Value
+		tag string
+		exp string
+	}{
+		{r, "", "Owner.Email: required check failed: value missing"},
+		{r.Field(0), "", "Email: required check failed: value missing"},
+		{r.Field(1), "min:3", "min check failed: len 1 is less than 3"},
+		{reflect.ValueOf(&row{Owner: user{"bob@example.com"}}), "", ""},
+		{reflect.Value{}, "required", "required check failed: value missing"},
+	}
+
+	for _, tc := range testCases {
+		if act := errString(ValidateValue(tc.val, tc.tag)); act != tc.exp {
+			t.Fatalf("Expected %q got %q", tc.exp, act)
+		}
+	}
+}
+
+type option[T any] struct {
+	val T
+	ok  bool
+}
+
+func TestRegisterExtractor(t *testing.T) {
+	t.Parallel()
+
+	type (
+		loop struct{ n int }
+
+		user struct {
+			Nick  option[string] `validate:"min:3"`
+			Email option[string] `validate:"required,email"`
+			Age   *option[int]   `validate:"omitnil,min:18"`
+			Loop  loop           `validate:"required"`
+		}
+	)
+
+	RegisterExtractor(reflect.TypeFor[option[string]](), func(val reflect.Value) any {
+		if o := val.Interface().(option[string]); o.ok { //nolint:forcetypeassert // registered for it
+			return o.val
+		}
+
+		return nil
+	})
+	RegisterExtractor(reflect.TypeFor[option[int]](), func(val reflect.Value) any {
+		return val.Interface().(option[int]).val //nolint:forcetypeassert // registered for it
+	})
+	RegisterExtractor(reflect.TypeFor[loop](), func(val reflect.Value) any { return val.Interface() })
+
+	t.Cleanup(func() {
+		RegisterExtractor(reflect.TypeFor[option[string]](), nil)
+		RegisterExtractor(reflect.TypeFor[option[int]](), nil)
+		RegisterExtractor(reflect.TypeFor[loop](), nil)
+	})
+
+	email := option[string]{"bob@example.com", true}
+
+	testCases := []struct {
+		val user
+		exp string
+	}{
+		{user{Email: email, Loop: loop{1}}, ""},
+		{user{Loop: loop{1}}, "Email: required check failed: value missing"},
+		{user{Email: option[string]{"bob", true}}, `Email: email check failed: "bob" is not a valid email address`},
+		{user{Nick: option[string]{"al", true}, Email: email}, "Nick: min check failed: len 2 is less than 3"},
+		{user{Email: email, Age: &option[int]{val: 16}}, "Age: min check failed: 16 is less than 18"},
+		{user{Email: email}, "Loop: required check failed: value missing"},
+	}
+
+	for _, tc := range testCases {
+		if act := errString(Validate(tc.val)); act != tc.exp {
+			t.Fatalf("Expected %q got %q", tc.exp, act)
+		}
+	}
+}
+
+func TestValidateGroup(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		ID    string `validate:"required@update,uuid"`
+		Name  string `validate:"required@create@update"`
+		Email string `validate:"regex:^[^@]+@[^@]+$"`
+		Note  string `validate:"one_of:a\\,b|c@import"`
+	}
+
+	testCases := []struct {
+		val    user
+		groups []string
+		exp    string
+	}{
+		{user{}, nil, ""},
+		{user{Email: "x"}, nil, "Email: regex check failed: \"x\" does not match ^[^@]+@[^@]+$"},
+		{user{}, []string{"create"}, "Name: required check failed: value missing"},
+		{user{Name: "x"}, []string{"create"}, ""},
+		{user{Name: "x"}, []string{"update"}, "ID: required check failed: value missing"},
+		{user{Name: "x"}, []string{"import", "update"}, "ID: required check failed: value missing"},
+		{user{Note: "long"}, nil, ""},
+		{user{Note: "a,b"}, []string{"import"}, ""},
+		{user{Note: "long"}, []string{"import"}, "Note: one_of check failed: \"long\" does not match ^(a,b|c)$"},
+	}
+
+	for _, tc := range testCases {
+		if err := ValidateGroup(tc.val, tc.groups...); errString(err) != tc.exp {
+			t.Fatalf("Expected %q got %v for %v", tc.exp, err, tc.groups)
+		}
+	}
+
+	d, err := Describe(reflect.TypeFor[user]())
+	if exp := []string{"create", "update"}; err != nil || !slices.Equal(d[1].Checks[0].Groups, exp) {
+		t.Fatalf("Expected %v got %v, %v", exp, d, err)
+	}
+
+	v := New()
+	v.GroupSep = ""
+
+	if err := v.CheckTag("required@create"); !errors.Is(err, ErrInvalidChecker) {
+		t.Fatalf("Expected %v got %v", ErrInvalidChecker, err)
+	}
+}
+
+func TestValidateDefault(t *testing.T) {
+	t.Parallel()
+
+	type config struct {
+		Host    string        `validate:"default:localhost"`
+		Port    *int          `validate:"default:8080,between:1|65535"`
+		Debug   bool          `validate:"default:true"`
+		Ratio   float32       `validate:"default:0.5"`
+		Timeout time.Duration `validate:"default:5s"`
+		Since   time.Time     `validate:"default:now-1h"`
+		Addr    netip.Addr    `validate:"default:127.0.0.1"`
+		Retries uint8         `validate:"default:3,min:5"`
+	}
+
+	cfg := config{Retries: 7}
+	if err := Validate(&cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Host != "localhost" || cfg.Port == nil || *cfg.Port != 8080 || !cfg.Debug || cfg.Ratio != 0.5 ||
+		cfg.Timeout != 5*time.Second || time.Since(cfg.Since) < time.Hour || cfg.Addr.String() != "127.0.0.1" {
+		t.Fatalf("Unexpected defaults %+v", cfg)
+	}
+
+	port := 0
+	if cfg = (config{Port: &port, Host: "x"}); errString(Validate(&cfg)) != "Retries: min check failed: 3 is less than 5" ||
+		*cfg.Port != 8080 || cfg.Host != "x" {
+		t.Fatalf("Expected the defaults to apply through pointers, then the checks, got %+v", cfg)
+	}
+
+	testCases := []struct {
+		val any
+		exp string
+	}{
+		{config{Retries: 7}, ""}, // Not settable.
+		{&struct {
+			N int `validate:"default:x"`
+		}{}, `N: invalid checker default:x: strconv.ParseInt: parsing "x": invalid syntax`},
+		{&struct {
+			N []int `validate:"default:1"`
+		}{}, "N: invalid checker default:1: no default for []int"},
+	}
+
+	for _, tc := range testCases {
+		if err := Validate(tc.val); errString(err) != tc.exp {
+			t.Fatalf("Expected %q got %v", tc.exp, err)
+		}
+	}
+}
+
+func TestValidateTransforms(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		Email string  `validate:"trim,lower,email"`
+		Name  *string `validate:"squish,required"`
+		Code  string  `validate:"trim,upper,default:N/A"`
+		Raw   string  `validate:"lower"`
+	}
+
+	name := "  Jane \t  Doe \n"
+	u := user{Email: "  Foo@Bar.COM ", Name: &name, Code: "   "}
+
+	if err := Validate(&u); err != nil {
+		t.Fatal(err)
+	}
+
+	if u.Email != "foo@bar.com" || *u.Name != "Jane Doe" || u.Code != "N/A" {
+		t.Fatalf("Unexpected transforms %+v", u)
+	}
+
+	if u = (user{Email: " Foo@Bar.COM ", Raw: "ABC"}); Validate(u) == nil || u.Raw != "ABC" {
+		t.Fatal("Expected the unsettable values to be validated as they are")
+	}
+
+	if s := "  x  "; Validate(&s, "trim,max:1") != nil || s != "x" {
+		t.Fatalf("Expected the top level value to be trimmed, got %q", s)
+	}
+
+	if u = (user{Email: "x@y.z", Name: p("  ")}); errString(Validate(&u)) != "Name: required check failed: value missing" {
+		t.Fatal("Expected the checks to run after the transforms")
+	}
+}
+
+func TestValidateAll(t *testing.T) {
+	t.Parallel()
+
+	type row struct {
+		Email string `validate:"required,email"`
+		Age   int    `validate:"max:150"`
+	}
+
+	rows := []row{{"a@b.c", 1}, {"", 2}, {"x@y.z", 200}, {"d@e.f", 3}}
+
+	errs := ValidateAll(rows)
+	if len(errs) != 2 || !errors.Is(errs[1], ErrRequired) || errString(errs[2]) != "Age: max check failed: 200 is more than 150" {
+		t.Fatalf("Unexpected errors %v", errs)
+	}
+
+	if exp := "[1]: Email: required check failed: value missing\n[2]: Age: max check failed: 200 is more than 150"; errs.Error() != exp {
+		t.Fatalf("Expected %q got %q", exp, errs.Error())
+	}
+
+	if !errors.Is(errs, ErrRequired) {
+		t.Fatalf("Expected %v to wrap %v", errs, ErrRequired)
+	}
+
+	if errs = ValidateAll(&[2]row{{"a@b.c", 1}}); len(errs) != 1 || errs[1] == nil {
+		t.Fatalf("Expected the second item to fail got %v", errs)
+	}
+
+	if errs = ValidateAll(rows[:1]); errs != nil {
+		t.Fatalf("Expected no errors got %v", errs)
+	}
+
+	if errs = ValidateAll([]string{"1", "x", "3"}, "numeric"); len(errs) != 1 || errs[1] == nil {
+		t.Fatalf("Expected the tags to apply to each item got %v", errs)
+	}
+
+	if errs = ValidateAll(row{}); len(errs) != 1 || !errors.Is(errs[0], ErrRequired) {
+		t.Fatalf("Expected a single item at index 0 got %v", errs)
+	}
+}
+
+func TestValidateOrder(t *testing.T) {
+	t.Parallel()
+
+	type payment struct {
+		Amount int    `validate:"required,min:1"`
+		Kind   string `validate:"required,one_of:bank|card,order:-1"`
+		Note   string `validate:"max:3,order:1"`
+	}
+
+	var calls int
+
+	v := New()
+	v.MaxErrors = -1
+	v.RegisterChecker("slow", func(reflect.Value) error {
+		calls++
+		return errors.New("too slow")
+	})
+
+	exp := "Kind: required check failed: value missing\n" +
+		"Amount: required check failed: value missing\n" +
+		"Note: max check failed: len 4 is more than 3"
+	if act := errString(v.Validate(payment{Note: "long"})); act != exp {
+		t.Fatalf("Expected %q got %q", exp, act)
+	}
+
+	v.CheckOrder = func(check string) int {
+		if check == "slow" {
+			return 1
+		}
+
+		return 0
+	}
+
+	v.MaxErrors = 0
+
+	if err := v.Validate("abcd", "slow,max:3"); errString(err) != "max check failed: len 4 is more than 3" || calls != 0 {
+		t.Fatalf("Expected the slow check to be skipped got %v after %d calls", err, calls)
+	}
+
+	if err := v.Validate("abc", "slow,max:3"); errString(err) != "slow check failed: too slow" || calls != 1 {
+		t.Fatalf("Expected the slow check to run last got %v after %d calls", err, calls)
+	}
+
+	if err := v.Validate(struct {
+		A string `validate:"order:x"`
+	}{}); !errors.Is(err, ErrInvalidChecker) {
+		t.Fatalf("Expected %v got %v", ErrInvalidChecker, err)
+	}
+}
+
+func TestValidateOpts(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		ID    string `validate:"required@update"`
+		Name  string `validate:"required"`
+		Email string `validate:"required"`
+	}
+
+	v := New()
+	v.MaxErrors = -1
+	v.ErrorFormatter = func(fe FieldError) string {
+		if fe.Locale == "fr" {
+			return fe.Field() + " est obligatoire"
+		}
+
+		return fe.Error()
+	}
+
+	testCases := []struct {
+		opts Opts
+		exp  string
+	}{
+		{Opts{}, "Name: required check failed: value missing\nEmail: required check failed: value missing"},
+		{Opts{FailFast: true}, "Name: required check failed: value missing"},
+		{Opts{MaxErrors: 1}, "Name: required check failed: value missing\ntoo many errors, stopped after 1"},
+		{Opts{Locale: "fr", FailFast: true}, "Name est obligatoire"},
+		{Opts{Groups: []string{"update"}, FailFast: true}, "ID: required check failed: value missing"},
+	}
+
+	for _, tc := range testCases {
+		if act := errString(v.ValidateOpts(user{}, tc.opts)); act != tc.exp {
+			t.Fatalf("Expected %q got %q for %+v", tc.exp, act, tc.opts)
+		}
+	}
+
+	if err := ValidateOpts(user{Name: "x", Email: "x"}, Opts{Groups: []string{"create"}}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+}
+
+func TestValidatePartial(t *testing.T) {
+	t.Parallel()
+
+	type (
+		address struct {
+			City string `validate:"required"`
+		}
+
+		update struct {
+			Name  string    `validate:"required"`
+			Min   int       `validate:"expr:Min <= Max"`
+			Max   int       `validate:"max:100"`
+			Home  address   `validate:"required"`
+			Other []address `validate:"max:2"`
+		}
+	)
+
+	testCases := []struct {
+		val    update
+		fields []string
+		exp    string
+	}{
+		{update{}, nil, ""},
+		{update{Max: 5}, []string{"Max"}, ""},
+		{update{Max: 500}, []string{"Max"}, "Max: max check failed: 500 is more than 100"},
+		{update{Max: 500}, []string{"Name"}, "Name: required check failed: value missing"},
+		{update{Min: 10, Max: 5}, []string{"Min"}, `Min: expr check failed: "Min <= Max" is false`},
+		{update{Min: 10, Max: 5}, []string{"Max"}, ""},
+		{update{Home: address{}}, []string{"Home.City"}, "Home.City: required check failed: value missing"},
+		{update{Home: address{"X"}}, []string{"Home"}, ""},
+		{update{Other: []address{{"X"}, {}}}, []string{"Other"}, "Other[1].City: required check failed: value missing"},
+		{update{Other: []address{{"X"}, {}}}, []string{"Other[0]"}, ""},
+	}
+
+	for _, tc := range testCases {
+		if act := errString(ValidatePartial(tc.val, tc.fields...)); act != tc.exp {
+			t.Fatalf("Expected %q got %q for %v", tc.exp, act, tc.fields)
+		}
+	}
+
+	v := New()
+	v.PathSep = "/"
+	val := update{Other: []address{{}}}
+
+	if exp, act := "Other[0]/City: required check failed: value missing", errString(v.ValidatePartial(val, "Other[0]/City")); act != exp {
+		t.Fatalf("Expected %q got %q", exp, act)
+	}
+
+	if act := errString(v.ValidatePartial(val, "Other[0].City")); act != "" {
+		t.Fatalf("Expected no error got %q", act)
+	}
+}
+
+func TestValidateOmitEmpty(t *testing.T) {
+	t.Parallel()
+
+	type (
+		address struct {
+			City string `validate:"required"`
+		}
+
+		user struct {
+			ID    string   `validate:"omitempty,uuid"`
+			Nick  *string  `validate:"omitnil,min:3"`
+			Tags  []string `validate:"omitnil,max:2"`
+			Home  address  `validate:"omitempty"`
+			Email string   `validate:"email"`
+		}
+	)
+
+	testCases := []struct {
+		val      user
+		explicit bool
+		exp      string
+	}{
+		{user{}, false, ""},
+		{user{ID: "foo"}, false, `ID: uuid check failed: "foo" does not match (?i)^[0-9a-f]{8}-?[0-9a-f]{4}-?[0-9a-f]{4}-?[0-9a-f]{4}-?[0-9a-f]{12}$`},
+		{user{Nick: p("")}, false, "Nick: min check failed: len 0 is less than 3"},
+		{user{Nick: p("bob")}, false, ""},
+		{user{Tags: []string{}}, false, ""},
+		{user{Home: address{City: ""}}, false, ""},
+		{user{}, true, `Email: email check failed: "" is not a valid email address`},
+		{user{Email: "bob@example.com"}, true, ""},
+		{user{Email: "bob@example.com", Home: address{City: "Paris"}}, true, ""},
+	}
+
+	for _, tc := range testCases {
+		v := New()
+		v.ExplicitOmitEmpty = tc.explicit
+
+		if act := errString(v.Validate(tc.val)); act != tc.exp {
+			t.Fatalf("Expected %q got %q for %+v", tc.exp, act, tc.val)
+		}
+	}
+}
+
+func TestValidateEscapedSeparators(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		val any
+		tag string
+		exp string
+	}{
+		{"12", `regex:^\d{1\,3}$`, ""},
+		{"1234", `regex:^\d{1\,3}$,required`, `regex check failed: "1234" does not match ^\d{1,3}$`},
+		{"12", `regex:'^\d{1,3}$'`, ""},
+		{"1234", `required, regex:'^\d{1,3}$', min:1`, `regex check failed: "1234" does not match ^\d{1,3}$`},
+		{"it's", `regex:'^it's$'`, ""},
+		{"it's", `regex:^it's$`, ""},
+		{"a,b", `one_of:'a,b|c'`, ""},
+		{"a", `one_of:'a,b|c'`, `one_of check failed: "a" does not match ^(a,b|c)$`},
+		{"a,b", `!one_of:'a,b|c'`, `!one_of check failed: "a,b" must not pass one_of:'a,b|c'`},
+		{"", `regex:'^\d{1,3}$`, "invalid checker regex:'^\\d{1,3}$: unterminated quote"},
+	}
+
+	for _, tc := range testCases {
+		if act := errString(Validate(tc.val, tc.tag)); act != tc.exp {
+			t.Fatalf("Expected %q got %q for %q", tc.exp, act, tc.tag)
+		}
+	}
+
+	s, err := JSONSchema(struct {
+		Code string `validate:"regex:'^[A-Z]{2,3}$'"`
+	}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rx := s.Properties["Code"].Pattern; rx != "^[A-Z]{2,3}$" {
+		t.Fatalf("Unexpected pattern %q", rx)
+	}
+}
+
+func TestCheckTag(t *testing.T) {
+	t.Parallel()
+
+	for tag, ok := range map[string]bool{
+		"":                    true,
+		"required,uuid":       true,
+		"ipv4|one_of:a|b":     true,
+		"regex:'^[a-z]{2,}$'": true,
+		"bogus":               false,
+		"regex:[":             false,
+		"between:1":           false,
+		"expr:A <":            false,
+		"min:abc":             false,
+		"between:a|b":         false,
+		"max:now+1h":          true,
+	} {
+		if err := CheckTag(tag); (err == nil) != ok || (err != nil && !errors.Is(err, ErrInvalidChecker)) {
+			t.Fatalf("Unexpected error %v for %q", err, tag)
+		}
+	}
+}
+
+func TestCheckStruct(t *testing.T) {
+	t.Parallel()
+
+	type (
+		item struct {
+			SKU string `validate:"regex:["`
+		}
+
+		node struct {
+			Name     string  `validate:"required"`
+			Children []*node `validate:"max:3"`
+		}
+
+		order struct {
+			ID     string `validate:"required,uuid"`
+			Status string `validate:"one_of:new|paid"`
+			Skip   string `validate:"-"`
+			Total  int    `validate:"between:1"`
+			Count  int    `validate:"min:abc"`
+			Items  []item
+			Byname map[string]*item
+			Tree   node
+			At     time.Time `validate:"required"`
+		}
+	)
+
+	exp := "Total: invalid checker between:1: need 2 arguments, got 1\n" +
+		"Count: invalid checker min:abc: invalid argument \"abc\": not a number or a time\n" +
+		"Items[].SKU: invalid checker regex:[: error parsing regexp: missing closing ]: `[`\n" +
+		"Byname[].SKU: invalid checker regex:[: error parsing regexp: missing closing ]: `[`"
+
+	if act := errString(CheckStruct(reflect.TypeFor[*order]())); act != exp {
+		t.Fatalf("Expected %q got %q", exp, act)
+	}
+
+	if err := CheckStruct(reflect.TypeFor[node]()); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if err := CheckStruct(reflect.TypeFor[string]()); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	v := New()
+
+	v.LoadRules(Rules{"vali.node": {"Nope": "required"}})
+
+	if err := v.CheckStruct(reflect.TypeFor[order]()); !errors.Is(err, ErrInvalidChecker) {
+		t.Fatalf("Expected %v got %v", ErrInvalidChecker, err)
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	t.Parallel()
+
+	if act := ParseArgs(" 3 | 10 "); !slices.Equal(act, []string{"3", "10"}) {
+		t.Fatalf("Unexpected args %q", act)
+	}
+
+	v := New()
+	v.CheckArgListSep = ";"
+
+	if act := v.ParseArgs("3;10|20"); !slices.Equal(act, []string{"3", "10|20"}) {
+		t.Fatalf("Unexpected args %q", act)
+	}
+
+	v.CheckArgListSep = ""
+
+	if act := v.ParseArgs("3;10"); !slices.Equal(act, []string{"3;10"}) {
+		t.Fatalf("Unexpected args %q", act)
+	}
+}
+
+func TestParseTypedArgs(t *testing.T) {
+	t.Parallel()
+
+	if n, err := ParseIntArg(" 42 "); err != nil || n != 42 {
+		t.Fatalf("Expected 42 got %d, %v", n, err)
+	}
+
+	if lo, hi, err := ParseRangeArg("3|10"); err != nil || lo != 3 || hi != 10 {
+		t.Fatalf("Expected 3, 10 got %d, %d, %v", lo, hi, err)
+	}
+
+	kv, err := ParseKVArgs("min=3 | max = 10|strict")
+	if exp := map[string]string{"min": "3", "max": "10", "strict": ""}; err != nil || !maps.Equal(kv, exp) {
+		t.Fatalf("Expected %v got %v, %v", exp, kv, err)
+	}
+
+	testCases := []struct {
+		fn  func() error
+		exp string
+	}{
+		{func() (err error) { _, err = ParseIntArg("x"); return }, `invalid argument "x": not an integer`},
+		{func() (err error) { _, _, err = ParseRangeArg("3"); return }, `invalid argument "3": need 2 arguments, got 1`},
+		{func() (err error) { _, _, err = ParseRangeArg("3|x"); return }, `invalid argument "x": not an integer`},
+		{func() (err error) { _, _, err = ParseRangeArg("10|3"); return }, `invalid argument "10|3": 10 is greater than 3`},
+		{func() (err error) { _, err = ParseKVArgs("=3"); return }, `invalid argument "=3": missing key`},
+		{func() (err error) { _, err = ParseKVArgs("a=1|a=2"); return }, `invalid argument "a": duplicate key`},
+	}
+
+	for _, tc := range testCases {
+		if err := tc.fn(); !errors.Is(err, ErrInvalidArg) || err.Error() != tc.exp {
+			t.Fatalf("Expected %q got %v", tc.exp, err)
+		}
+	}
+}
+
+func TestValidateBetween(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		val any
+		tag string
+		exp string
+	}{
+		{5, "between:3|10", ""},
+		{3, "between:3|10", ""},
+		{11, "between:3|10", "between check failed: 11 is more than 10"},
+		{0, "between:3|10", "between check failed: 0 is less than 3"},
+		{"abcd", "between:3|10", ""},
+		{"ab", "between:3|10", "between check failed: len 2 is less than 3"},
+		{5, "between:3", "invalid checker between:3: need 2 arguments, got 1"},
+		{5, "between:3|x", `invalid checker between:3|x: invalid argument "x": not a number or a time`},
+		{5, "between:a|b", `invalid checker between:a|b: invalid argument "a": not a number or a time`},
+	}
+
+	for _, tc := range testCases {
+		if act := errString(Validate(tc.val, tc.tag)); act != tc.exp {
+			t.Fatalf("Expected %q got %q for %q", tc.exp, act, tc.tag)
+		}
+	}
+
+	v := New()
+	v.CheckArgListSep = ";"
+
+	for _, tc := range []struct{ tag, exp string }{
+		{"between:1;10", ""},
+		{"between:2;10", "between check failed: len 1 is less than 2"},
+		{"one_of:a;b;c", ""},
+		{"one_of:x;y", `one_of check failed: "b" does not match ^(x|y)$`},
+		{"csv:1;|", ""},
+	} {
+		if act := errString(v.Validate("b", tc.tag)); act != tc.exp {
+			t.Fatalf("Expected %q got %q for %q", tc.exp, act, tc.tag)
+		}
+	}
+}
+
+func TestValidateMessage(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		Email string `validate:"required,email,msg:'must be a work email, not a personal one'"`
+		Nick  string `validate:"msg:too short,min:3"`
+	}
+
+	testCases := []struct {
+		val user
+		exp string
+		is  error
+	}{
+		{user{Email: "bob@example.com", Nick: "bob"}, "", nil},
+		{user{Nick: "bob"}, "Email: required check failed: must be a work email, not a personal one", ErrRequired},
+		{user{Email: "bob", Nick: "bob"}, "Email: email check failed: must be a work email, not a personal one", ErrCheckFailed},
+		{user{Email: "bob@example.com", Nick: "al"}, "Nick: min check failed: too short", ErrCheckFailed},
+	}
+
+	for _, tc := range testCases {
+		err := Validate(tc.val)
+		if act := errString(err); act != tc.exp {
+			t.Fatalf("Expected %q got %q", tc.exp, act)
+		}
+
+		if !errors.Is(err, tc.is) {
+			t.Fatalf("Expected %v got %v", tc.is, err)
+		}
+	}
+
+	var u user
+
+	err := ValidateJSON([]byte(`{"Nick": "bob"}`), &u)
+	if act := errString(err); act != "Email: required check failed: must be a work email, not a personal one" {
+		t.Fatalf("Unexpected error %q", act)
+	}
+}
+
 func p[T any](v T) *T {
 	return &v
 }
@@ -0,0 +1,103 @@
+// Package valigrpc provides gRPC server interceptors that validate the
+// incoming request messages with [vali], rejecting the invalid ones with
+// an INVALID_ARGUMENT status carrying [errdetails.BadRequest] details.
+//
+// It lives in its own module, so that vali itself stays dependency free.
+package valigrpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/alexaandru/vali"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type serverStream struct {
+	grpc.ServerStream
+
+	v *vali.Validator
+}
+
+// UnaryServerInterceptor returns an interceptor validating the unary
+// requests using v (or the [vali.DefaultValidator] if v is nil).
+func UnaryServerInterceptor(v *vali.Validator) grpc.UnaryServerInterceptor {
+	v = orDefault(v)
+
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := v.Validate(req); err != nil {
+			return nil, Status(err).Err()
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns an interceptor validating every message
+// received on the stream using v (or the [vali.DefaultValidator] if v is nil).
+func StreamServerInterceptor(v *vali.Validator) grpc.StreamServerInterceptor {
+	v = orDefault(v)
+
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, v: v})
+	}
+}
+
+// RecvMsg receives and validates the next message.
+func (s *serverStream) RecvMsg(m any) (err error) {
+	if err = s.ServerStream.RecvMsg(m); err != nil {
+		return
+	}
+
+	if err = s.v.Validate(m); err != nil {
+		return Status(err).Err()
+	}
+
+	return
+}
+
+// Status converts a validation error into an INVALID_ARGUMENT status,
+// with a field violation for each [vali.FieldError]. Any other error
+// (i.e. an invalid checker) results in an INTERNAL status.
+func Status(err error) *status.Status {
+	var (
+		errs vali.Errors
+		fe   *vali.FieldError
+	)
+
+	switch {
+	case errors.As(err, &errs):
+	case errors.As(err, &fe):
+		errs = vali.Errors{fe}
+	default:
+		return status.New(codes.Internal, err.Error())
+	}
+
+	st := status.New(codes.InvalidArgument, err.Error())
+
+	br := &errdetails.BadRequest{}
+	for _, fe := range errs {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       fe.Field(),
+			Description: fe.Err.Error(),
+			Reason:      fe.Check,
+		})
+	}
+
+	if st2, err2 := st.WithDetails(br); err2 == nil {
+		st = st2
+	}
+
+	return st
+}
+
+func orDefault(v *vali.Validator) *vali.Validator {
+	if v == nil {
+		return vali.DefaultValidator
+	}
+
+	return v
+}
@@ -0,0 +1,128 @@
+package valigrpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/alexaandru/vali"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type (
+	createUserRequest struct {
+		Email string `protobuf:"bytes,1,opt,name=email,proto3" validate:"required,email"`
+	}
+
+	fakeStream struct {
+		grpc.ServerStream
+
+		msgs []string
+	}
+)
+
+func (f *fakeStream) RecvMsg(m any) error {
+	if len(f.msgs) == 0 {
+		return errors.New("EOF")
+	}
+
+	m.(*createUserRequest).Email, f.msgs = f.msgs[0], f.msgs[1:] //nolint:forcetypeassert // test
+
+	return nil
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	t.Parallel()
+
+	var called bool
+
+	handler := func(context.Context, any) (any, error) {
+		called = true
+		return "ok", nil
+	}
+
+	ic := UnaryServerInterceptor(nil)
+
+	resp, err := ic(t.Context(), &createUserRequest{Email: "bob@example.com"}, nil, handler)
+	if err != nil || resp != "ok" || !called {
+		t.Fatalf("Expected the handler to be called got %v, %v", resp, err)
+	}
+
+	called = false
+
+	_, err = ic(t.Context(), &createUserRequest{}, nil, handler)
+	if called {
+		t.Fatal("Expected the handler not to be called")
+	}
+
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("Expected %v got %v", codes.InvalidArgument, st.Code())
+	}
+
+	details := st.Details()
+	if len(details) != 1 {
+		t.Fatalf("Expected 1 detail got %d", len(details))
+	}
+
+	br, ok := details[0].(*errdetails.BadRequest)
+	if !ok {
+		t.Fatalf("Expected *errdetails.BadRequest got %T", details[0])
+	}
+
+	fv := br.GetFieldViolations()[0]
+	if fv.GetField() != "Email" || fv.GetReason() != "required" || fv.GetDescription() != "value missing" {
+		t.Fatalf("Unexpected violation %v", fv)
+	}
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	t.Parallel()
+
+	ic := StreamServerInterceptor(vali.New())
+	handler := func(_ any, ss grpc.ServerStream) (err error) {
+		for {
+			if err = ss.RecvMsg(&createUserRequest{}); err != nil {
+				return
+			}
+		}
+	}
+
+	err := ic(nil, &fakeStream{msgs: []string{"bob@example.com", "bob"}}, nil, handler)
+	if st := status.Convert(err); st.Code() != codes.InvalidArgument {
+		t.Fatalf("Expected %v got %v", codes.InvalidArgument, err)
+	}
+
+	err = ic(nil, &fakeStream{msgs: []string{"bob@example.com"}}, nil, handler)
+	if err == nil || err.Error() != "EOF" {
+		t.Fatalf("Expected EOF got %v", err)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	t.Parallel()
+
+	if st := Status(vali.ErrInvalidChecker); st.Code() != codes.Internal {
+		t.Fatalf("Expected %v got %v", codes.Internal, st.Code())
+	}
+
+	v := vali.New()
+	v.MaxErrors = -1
+
+	st := Status(v.Validate(struct {
+		Name  string `validate:"required"`
+		Email string `validate:"email"`
+	}{Email: "x"}))
+
+	br, ok := st.Details()[0].(*errdetails.BadRequest)
+	if !ok || st.Code() != codes.InvalidArgument || len(br.GetFieldViolations()) != 2 {
+		t.Fatalf("Expected 2 field violations got %v", st.Details())
+	}
+
+	if fv := br.GetFieldViolations()[1]; fv.GetField() != "Email" || fv.GetReason() != "email" {
+		t.Fatalf("Unexpected violation %v", fv)
+	}
+}
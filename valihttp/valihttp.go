@@ -0,0 +1,122 @@
+// Package valihttp glues [vali] to net/http handlers: it decodes JSON
+// request bodies, validates them and renders the failures as structured
+// 422 (Unprocessable Entity) responses.
+package valihttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/alexaandru/vali"
+)
+
+type (
+	// Response is the body written by [WriteError].
+	Response struct {
+		Error  string       `json:"error"`
+		Fields []FieldError `json:"fields,omitempty"`
+	}
+
+	// FieldError describes a single validation failure. The Field is
+	// a JSON pointer into the request body (see [vali.FieldError.JSONPath]).
+	FieldError struct {
+		Field   string `json:"field"`
+		Check   string `json:"check"`
+		Message string `json:"message"`
+		Hint    string `json:"hint,omitempty"`
+	}
+)
+
+// ErrDecode is returned when the request body cannot be decoded.
+var ErrDecode = errors.New("invalid request body")
+
+// MaxBodyBytes limits the size of the request bodies being decoded.
+var MaxBodyBytes int64 = 1 << 20
+
+// DecodeValid decodes the JSON body of r into a T and validates it using
+// the [vali.DefaultValidator]. See [DecodeValidWith] for details.
+func DecodeValid[T any](r *http.Request) (T, error) {
+	return DecodeValidWith[T](vali.DefaultValidator, r)
+}
+
+// DecodeValidWith decodes the JSON body of r into a T and validates it using v,
+// via [vali.Validator.ValidateJSON], so that `required` tells the absent keys
+// from the zero values. Decoding errors wrap [ErrDecode] (and [http.MaxBytesError],
+// for bodies over [MaxBodyBytes]), validation errors are returned as is.
+func DecodeValidWith[T any](v *vali.Validator, r *http.Request) (val T, err error) {
+	body := http.MaxBytesReader(nil, r.Body, MaxBodyBytes)
+	defer body.Close() //nolint:errcheck // nothing to do about it
+
+	data, err := io.ReadAll(body)
+	if err == nil {
+		err = json.Unmarshal(data, &val) // So that ValidateJSON only fails on validation.
+	}
+
+	if err != nil {
+		return val, fmt.Errorf("%w: %w", ErrDecode, err)
+	}
+
+	err = v.ValidateJSON(data, &val)
+
+	return
+}
+
+// Handler returns a [http.Handler] that decodes and validates the request
+// body (see [DecodeValid]) and, if valid, passes it on to fn. Otherwise it
+// responds with [WriteError].
+func Handler[T any](fn func(http.ResponseWriter, *http.Request, T)) http.Handler {
+	return HandlerWith(vali.DefaultValidator, fn)
+}
+
+// HandlerWith is the same as [Handler] but it validates using v.
+func HandlerWith[T any](v *vali.Validator, fn func(http.ResponseWriter, *http.Request, T)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		val, err := DecodeValidWith[T](v, r)
+		if err != nil {
+			WriteError(w, err)
+			return
+		}
+
+		fn(w, r, val)
+	})
+}
+
+// WriteError writes err as a JSON [Response]: validation failures get
+// a 422 status (with all the failures listed, when collected, see
+// [vali.Validator.MaxErrors]), decoding errors a 400 (or a 413, for bodies
+// that are too large) and anything else (i.e. invalid checkers) a 500.
+func WriteError(w http.ResponseWriter, err error) {
+	var (
+		errs   vali.Errors
+		fe     *vali.FieldError
+		tooBig *http.MaxBytesError
+		status = http.StatusInternalServerError
+		resp   = Response{Error: err.Error()}
+	)
+
+	switch {
+	case errors.As(err, &errs), errors.As(err, &fe):
+		if errs == nil {
+			errs = vali.Errors{fe}
+		}
+
+		status = http.StatusUnprocessableEntity
+		resp.Error = vali.ErrCheckFailed.Error()
+
+		for _, fe := range errs {
+			resp.Fields = append(resp.Fields, FieldError{Field: fe.JSONPath(), Check: fe.Check, Message: fe.Err.Error(), Hint: fe.Hint})
+		}
+	case errors.As(err, &tooBig):
+		status = http.StatusRequestEntityTooLarge
+	case errors.Is(err, ErrDecode):
+		status = http.StatusBadRequest
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(resp) //nolint:errchkjson // nothing to do about it
+}
@@ -0,0 +1,177 @@
+package valihttp
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alexaandru/vali"
+)
+
+type (
+	signup struct {
+		Email string `json:"email" validate:"required,email"`
+		Age   int    `json:"age"   validate:"min:18"`
+	}
+
+	order struct {
+		Address struct {
+			ZipCode string `json:"zip_code" validate:"required,eq:5"`
+		} `json:"address"`
+		Notes int `json:"notes" validate:"required"`
+	}
+)
+
+func TestDecodeValid(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		body   string
+		expErr error
+	}{
+		{`{"email": "bob@example.com", "age": 20}`, nil},
+		{`{"email": "bob", "age": 20}`, vali.ErrCheckFailed},
+		{`{"age": 20}`, vali.ErrRequired},
+		{`{"email": `, ErrDecode},
+		{``, ErrDecode},
+	}
+
+	for _, tc := range testCases {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+
+		val, err := DecodeValid[signup](r)
+		if !errors.Is(err, tc.expErr) {
+			t.Fatalf("Expected %v got %v for %s", tc.expErr, err, tc.body)
+		}
+
+		if err == nil && val.Email != "bob@example.com" {
+			t.Fatalf("Unexpected value %#v", val)
+		}
+	}
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	h := Handler(func(w http.ResponseWriter, _ *http.Request, s signup) {
+		_, _ = w.Write([]byte("hello " + s.Email))
+	})
+
+	testCases := []struct {
+		body   string
+		exp    string
+		status int
+	}{
+		{`{"email": "bob@example.com", "age": 20}`, `hello bob@example.com`, http.StatusOK},
+		{
+			`{"email": "bob@example.com", "age": 2}`,
+			`{"error":"check failed","fields":[{"field":"/age","check":"min","message":"2 is less than 18"}]}` + "\n",
+			http.StatusUnprocessableEntity,
+		},
+		{
+			`{"age": 20}`,
+			`{"error":"check failed","fields":[{"field":"/email","check":"required","message":"value missing"}]}` + "\n",
+			http.StatusUnprocessableEntity,
+		},
+		{`nope`, `{"error":"invalid request body: invalid character 'o' in literal null (expecting 'u')"}` + "\n", http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body)))
+
+		if w.Code != tc.status {
+			t.Fatalf("Expected status %d got %d", tc.status, w.Code)
+		}
+
+		if act := w.Body.String(); act != tc.exp {
+			t.Fatalf("Expected %q got %q", tc.exp, act)
+		}
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+	WriteError(w, vali.ErrInvalidChecker)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("Expected status %d got %d", http.StatusInternalServerError, w.Code)
+	}
+
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("Unexpected content type %q", ct)
+	}
+
+	v := vali.New()
+	v.MaxErrors = -1
+
+	w = httptest.NewRecorder()
+	WriteError(w, v.Validate(struct {
+		Name  string `validate:"required"`
+		Email string `validate:"email"`
+	}{Email: "x"}))
+
+	exp := `{"error":"check failed","fields":[{"field":"/Name","check":"required","message":"value missing"},` +
+		`{"field":"/Email","check":"email","message":"\"x\" is not a valid email address"}]}` + "\n"
+	if act := w.Body.String(); w.Code != http.StatusUnprocessableEntity || act != exp {
+		t.Fatalf("Expected %q got %d %q", exp, w.Code, act)
+	}
+}
+
+func TestMaxBodyBytes(t *testing.T) { //nolint:paralleltest // changes a global
+	defer func(n int64) { MaxBodyBytes = n }(MaxBodyBytes)
+
+	MaxBodyBytes = 8
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"email": "bob@example.com"}`))
+
+	_, err := DecodeValid[signup](r)
+	if !errors.Is(err, ErrDecode) {
+		t.Fatalf("Expected %v got %v", ErrDecode, err)
+	}
+
+	w := httptest.NewRecorder()
+	WriteError(w, err)
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("Expected status %d got %d", http.StatusRequestEntityTooLarge, w.Code)
+	}
+}
+
+func TestHandlerJSON(t *testing.T) {
+	t.Parallel()
+
+	h := Handler(func(w http.ResponseWriter, _ *http.Request, o order) {
+		_, _ = w.Write([]byte("ok " + o.Address.ZipCode))
+	})
+
+	testCases := []struct {
+		body   string
+		exp    string
+		status int
+	}{
+		{`{"address": {"zip_code": "12345"}, "notes": 0}`, `ok 12345`, http.StatusOK},
+		{
+			`{"address": {"zip_code": "123"}, "notes": 0}`,
+			`{"error":"check failed","fields":[{"field":"/address/zip_code","check":"eq","message":"len 3 is not equal to 5"}]}` + "\n",
+			http.StatusUnprocessableEntity,
+		},
+		{
+			`{"address": {"zip_code": "12345"}}`,
+			`{"error":"check failed","fields":[{"field":"/notes","check":"required","message":"value missing"}]}` + "\n",
+			http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body)))
+
+		if act := w.Body.String(); w.Code != tc.status || act != tc.exp {
+			t.Fatalf("Expected %d %q got %d %q", tc.status, tc.exp, w.Code, act)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Package valiotel adds OpenTelemetry tracing to [vali]: spans around the
+// validations and around the expensive (i.e. network touching) checkers.
+// It is activated by the context passed in: the spans are created by the
+// tracer provider of the span it carries, if any (no-op, otherwise).
+//
+// It lives in its own module, so that vali itself stays dependency free.
+package valiotel
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"github.com/alexaandru/vali"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope name of the tracer used.
+const ScopeName = "github.com/alexaandru/vali/valiotel"
+
+// ValidateContext validates val using v (or the [vali.DefaultValidator] if
+// v is nil), see [vali.Validator.ValidateContext], within a "vali.Validate"
+// span, recording the type validated and the number of failures found.
+func ValidateContext(ctx context.Context, v *vali.Validator, val any, tags ...string) (err error) {
+	v = orDefault(v)
+
+	ctx, span := tracer(ctx).Start(ctx, "vali.Validate",
+		trace.WithAttributes(attribute.String("vali.type", typeName(val))))
+	defer span.End()
+
+	err = v.ValidateContext(ctx, val, tags...)
+
+	span.SetAttributes(attribute.Int("vali.errors", errorCount(err)))
+	endSpan(span, err)
+
+	return
+}
+
+// Checker wraps cc, a context checker registered as name, so that each of
+// its calls gets its own "vali.check <name>" span, i.e.:
+//
+//	v.RegisterContextChecker("email_mx", valiotel.Checker("email_mx", vali.EmailMX(nil, 3*time.Second)))
+func Checker(name string, cc vali.ContextChecker) vali.ContextChecker {
+	return func(ctx context.Context, val reflect.Value) (err error) {
+		ctx, span := tracer(ctx).Start(ctx, "vali.check "+name,
+			trace.WithAttributes(attribute.String("vali.check", name)))
+		defer span.End()
+
+		err = cc(ctx, val)
+		endSpan(span, err)
+
+		return
+	}
+}
+
+func tracer(ctx context.Context) trace.Tracer {
+	return trace.SpanFromContext(ctx).TracerProvider().Tracer(ScopeName)
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+// errorCount returns the number of failures in err: one per [vali.FieldError]
+// (all of them, when collected) or one for any other error.
+func errorCount(err error) int {
+	var errs vali.Errors
+
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &errs):
+		return len(errs)
+	default:
+		return 1
+	}
+}
+
+func typeName(val any) string {
+	t := reflect.TypeOf(val)
+	if t == nil {
+		return "<nil>"
+	}
+
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t.String()
+}
+
+func orDefault(v *vali.Validator) *vali.Validator {
+	if v == nil {
+		return vali.DefaultValidator
+	}
+
+	return v
+}
@@ -0,0 +1,89 @@
+package valiotel
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/alexaandru/vali"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type signup struct {
+	Email string `validate:"required,slow"`
+	Name  string `validate:"required"`
+}
+
+func TestValidateContext(t *testing.T) {
+	t.Parallel()
+
+	rec := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))
+
+	v := vali.New()
+	v.MaxErrors = -1
+	v.RegisterContextChecker("slow", Checker("slow", func(context.Context, reflect.Value) error {
+		return errors.New("too slow")
+	}))
+
+	// No span in the context, no tracing.
+	if err := ValidateContext(t.Context(), v, &signup{Email: "x", Name: "y"}); err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	if n := len(rec.Ended()); n != 0 {
+		t.Fatalf("Expected no spans got %d", n)
+	}
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "request")
+
+	err := ValidateContext(ctx, v, &signup{Email: "x"})
+	parent.End()
+
+	if !errors.Is(err, vali.ErrCheckFailed) {
+		t.Fatalf("Expected %v got %v", vali.ErrCheckFailed, err)
+	}
+
+	spans := rec.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("Expected 3 spans got %d", len(spans))
+	}
+
+	check, validate := spans[0], spans[1]
+
+	if check.Name() != "vali.check slow" || check.Parent().SpanID() != validate.SpanContext().SpanID() ||
+		check.Status().Code != codes.Error {
+		t.Fatalf("Unexpected check span %s %v", check.Name(), check.Status())
+	}
+
+	if validate.Name() != "vali.Validate" || validate.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Fatalf("Unexpected validate span %s", validate.Name())
+	}
+
+	exp := []attribute.KeyValue{attribute.String("vali.type", "valiotel.signup"), attribute.Int("vali.errors", 2)}
+	if act := validate.Attributes(); !reflect.DeepEqual(act, exp) {
+		t.Fatalf("Expected %v got %v", exp, act)
+	}
+}
+
+func TestErrorCount(t *testing.T) {
+	t.Parallel()
+
+	for err, exp := range map[error]int{
+		nil:                           0,
+		vali.ErrInvalidChecker:        1,
+		vali.Validate("", "required"): 1,
+	} {
+		if act := errorCount(err); act != exp {
+			t.Fatalf("Expected %d got %d for %v", exp, act, err)
+		}
+	}
+
+	if exp, act := "<nil>", typeName(nil); act != exp {
+		t.Fatalf("Expected %q got %q", exp, act)
+	}
+}
@@ -0,0 +1,111 @@
+// Package valitest provides test helpers for custom checkers, checker makers
+// and aliases, so that they can be tested with tags, just like they are used:
+//
+//	valitest.AssertPasses(t, v, "EUR", "currency")
+//	valitest.AssertFails(t, v, "eur", "currency", "ErrCheckFailed")
+//
+// Failure messages can be compared against golden files, see [AssertGolden].
+package valitest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alexaandru/vali"
+)
+
+// UpdateEnv is the environment variable which, when set to a non empty
+// value, makes [AssertGolden] (re)write the golden files, rather than
+// comparing against them (i.e. `VALITEST_UPDATE=1 go test ./...`).
+const UpdateEnv = "VALITEST_UPDATE"
+
+// sentinels are the [vali] errors [AssertFails] can match by name.
+var sentinels = map[string]error{
+	"ErrCheckFailed":    vali.ErrCheckFailed,
+	"ErrRequired":       vali.ErrRequired,
+	"ErrInvalidChecker": vali.ErrInvalidChecker,
+	"ErrInvalidCmp":     vali.ErrInvalidCmp,
+}
+
+// AssertPasses fails the test if val does not pass the tag checks,
+// using v (or the [vali.DefaultValidator] if v is nil).
+func AssertPasses(t testing.TB, v *vali.Validator, val any, tag string) {
+	t.Helper()
+
+	if err := orDefault(v).Validate(val, tag); err != nil {
+		t.Fatalf("Expected %#v to pass %q, got %v", val, tag, err)
+	}
+}
+
+// AssertFails fails the test unless val fails the tag checks, using v
+// (or the [vali.DefaultValidator] if v is nil), with the want error.
+// That is either the name of a [vali] sentinel error (i.e. "ErrCheckFailed"
+// or "ErrRequired"), matched via [errors.Is], or a substring of the error
+// message. An empty want matches any failure.
+func AssertFails(t testing.TB, v *vali.Validator, val any, tag, want string) {
+	t.Helper()
+
+	err := orDefault(v).Validate(val, tag)
+	if err == nil {
+		t.Fatalf("Expected %#v to fail %q", val, tag)
+	}
+
+	if !matches(err, want) {
+		t.Fatalf("Expected %#v to fail %q with %s, got %v", val, tag, want, err)
+	}
+}
+
+// AssertGolden compares the message of err (empty for nil) against the content
+// of the golden file, which is (re)written instead when [UpdateEnv] is set.
+func AssertGolden(t testing.TB, err error, golden string) {
+	t.Helper()
+
+	var act string
+	if err != nil {
+		act = err.Error() + "\n"
+	}
+
+	if os.Getenv(UpdateEnv) != "" {
+		if err = os.MkdirAll(filepath.Dir(golden), 0o750); err != nil {
+			t.Fatal(err)
+		}
+
+		if err = os.WriteFile(golden, []byte(act), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		return
+	}
+
+	exp, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("%v (set %s=1 to create it)", err, UpdateEnv)
+	}
+
+	if act != string(exp) {
+		t.Fatalf("Error mismatch for %s:\nexpected: %q\n     got: %q", golden, exp, act)
+	}
+}
+
+func matches(err error, want string) bool {
+	if want == "" {
+		return true
+	}
+
+	if sentinel, ok := sentinels[want]; ok {
+		return errors.Is(err, sentinel)
+	}
+
+	return strings.Contains(err.Error(), want)
+}
+
+func orDefault(v *vali.Validator) *vali.Validator {
+	if v == nil {
+		return vali.DefaultValidator
+	}
+
+	return v
+}
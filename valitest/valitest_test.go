@@ -0,0 +1,108 @@
+package valitest
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/alexaandru/vali"
+)
+
+// fakeT records the failure of the assertion it is passed to.
+type fakeT struct {
+	testing.TB
+
+	failure string
+}
+
+func (*fakeT) Helper() {}
+
+func (f *fakeT) Fatal(args ...any) {
+	f.failure = fmt.Sprint(args...)
+	runtime.Goexit()
+}
+
+func (f *fakeT) Fatalf(format string, args ...any) {
+	f.failure = fmt.Sprintf(format, args...)
+	runtime.Goexit()
+}
+
+// run runs assert, returning its failure message, if any.
+func run(assert func(testing.TB)) string {
+	f, done := &fakeT{}, make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		assert(f)
+	}()
+
+	<-done
+
+	return f.failure
+}
+
+func TestAssertPassesFails(t *testing.T) {
+	t.Parallel()
+
+	v := vali.New()
+	v.RegisterChecker("even", func(val reflect.Value) error {
+		if val.Int()%2 != 0 {
+			return errors.New("odd number")
+		}
+
+		return nil
+	})
+
+	testCases := []struct {
+		assert func(testing.TB)
+		exp    string
+	}{
+		{func(t testing.TB) { AssertPasses(t, v, 2, "even") }, ""},
+		{func(t testing.TB) { AssertPasses(t, nil, "abc", "alpha") }, ""},
+		{func(t testing.TB) { AssertPasses(t, v, 3, "even") }, `Expected 3 to pass "even", got even check failed: odd number`},
+		{func(t testing.TB) { AssertFails(t, v, 3, "even", "ErrCheckFailed") }, ""},
+		{func(t testing.TB) { AssertFails(t, v, 3, "even", "odd number") }, ""},
+		{func(t testing.TB) { AssertFails(t, v, 3, "even", "") }, ""},
+		{func(t testing.TB) { AssertFails(t, v, 0, "required", "ErrRequired") }, ""},
+		{func(t testing.TB) { AssertFails(t, v, 1, "bogus", "ErrInvalidChecker") }, ""},
+		{func(t testing.TB) { AssertFails(t, v, 2, "even", "") }, `Expected 2 to fail "even"`},
+		{func(t testing.TB) { AssertFails(t, v, 3, "even", "ErrRequired") }, `Expected 3 to fail "even" with ErrRequired, got even check failed: odd number`},
+		{func(t testing.TB) { AssertFails(t, v, 3, "even", "too big") }, `Expected 3 to fail "even" with too big, got even check failed: odd number`},
+	}
+
+	for _, tc := range testCases {
+		if act := run(tc.assert); act != tc.exp {
+			t.Fatalf("Expected %q got %q", tc.exp, act)
+		}
+	}
+}
+
+//nolint:paralleltest // it sets the environment
+func TestAssertGolden(t *testing.T) {
+	err := vali.Validate(struct {
+		Name string `validate:"required"`
+	}{})
+
+	AssertGolden(t, err, "testdata/required.golden")
+	AssertGolden(t, nil, "testdata/empty.golden")
+
+	fn := filepath.Join(t.TempDir(), "new", "x.golden")
+
+	if act := run(func(t testing.TB) { AssertGolden(t, err, fn) }); act == "" {
+		t.Fatal("Expected a missing golden file to fail")
+	}
+
+	t.Setenv(UpdateEnv, "1")
+	AssertGolden(t, err, fn)
+	t.Setenv(UpdateEnv, "")
+
+	AssertGolden(t, err, fn)
+
+	if act := run(func(t testing.TB) { AssertGolden(t, nil, fn) }); act == "" {
+		t.Fatal("Expected a mismatch to fail")
+	}
+}
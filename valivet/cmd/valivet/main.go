@@ -0,0 +1,14 @@
+// Command valivet checks the vali struct tags, run it via go vet:
+//
+//	go install github.com/alexaandru/vali/valivet/cmd/valivet@latest
+//	go vet -vettool=$(which valivet) ./...
+package main
+
+import (
+	"github.com/alexaandru/vali/valivet"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(valivet.Analyzer)
+}
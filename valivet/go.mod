@@ -0,0 +1,15 @@
+module github.com/alexaandru/vali/valivet
+
+go 1.25.6
+
+require (
+	github.com/alexaandru/vali v0.0.0
+	golang.org/x/tools v0.44.0
+)
+
+require (
+	golang.org/x/mod v0.36.0 // indirect
+	golang.org/x/sync v0.22.0 // indirect
+)
+
+replace github.com/alexaandru/vali => ../
@@ -0,0 +1,18 @@
+package a
+
+type User struct {
+	ID     string `validate:"required,uuid"`
+	Email  string `validate:"required,emial"` // want `invalid validate tag: invalid checker emial`
+	Code   string `validate:"regex:^[A-Z"`    // want `invalid validate tag: invalid checker regex:\^\[A-Z: error parsing regexp`
+	Age    int    `validate:"between:18"`     // want `invalid validate tag: invalid checker between:18: need 2 arguments, got 1`
+	Score  int    `validate:"min:abc"`        // want `invalid validate tag: invalid checker min:abc: invalid argument "abc": not a number or a time`
+	Role   string `validate:"admin_role"`
+	Custom string `validate:"custom_of:a|b"`
+	secret string `validate:"required"` // want `validate tag on unexported field secret`
+	Nested struct {
+		Zip string `json:"zip" validate:"numeric,eq:5"`
+		Bad string `validate:"min"` // want `invalid validate tag: invalid checker min`
+	}
+	Skipped string `validate:"-"`
+	NoTag   string
+}
@@ -0,0 +1,10 @@
+package b
+
+type User struct {
+	ID    string `validate:"Required,UUID"`
+	Email string `validate:"OmitEmpty,Email"`
+	Role  string `validate:"One_Of:admin|user"`
+	Nick  string `validate:"Bogus"`         // want `invalid validate tag: invalid checker Bogus`
+	Name  string `binding:"Required,Bogus"` // want `invalid binding tag: invalid checker Bogus`
+	Age   int    `binding:"Bogus" validate:"Min:1"`
+}
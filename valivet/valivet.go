@@ -0,0 +1,131 @@
+// Package valivet provides an [analysis.Analyzer] that checks the vali
+// struct tags at build time, reporting unknown checkers, malformed
+// arguments (i.e. regexes that don't compile) and tags on unexported
+// fields, so that [vali.ErrInvalidChecker] surfaces in CI rather than
+// at runtime. See the valivet command for running it via `go vet`.
+//
+// It lives in its own module, so that vali itself stays dependency free.
+package valivet
+
+import (
+	"go/ast"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/alexaandru/vali"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzer reports the invalid vali struct tags.
+var Analyzer = &analysis.Analyzer{
+	Name:     "valivet",
+	Doc:      "check vali struct tags for unknown checkers and malformed arguments",
+	URL:      "https://pkg.go.dev/github.com/alexaandru/vali/valivet",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// Flags.
+var (
+	tagName         = vali.DefaultValidatorTagName
+	checkers        string
+	unexported      = true
+	caseInsensitive bool
+)
+
+func init() { //nolint:gochecknoinits // that's how analyzers get their flags
+	Analyzer.Flags.StringVar(&tagName, "tag", tagName,
+		"the struct tag name, or a comma separated list of them, in the fallback order (see vali.New)")
+	Analyzer.Flags.StringVar(&checkers, "checkers", "",
+		"comma separated list of the custom checkers (and checker makers) registered at runtime")
+	Analyzer.Flags.BoolVar(&unexported, "unexported", unexported, "report the tags on unexported fields")
+	Analyzer.Flags.BoolVar(&caseInsensitive, "case-insensitive", caseInsensitive,
+		"resolve the checker names case insensitively (see vali.Validator.CaseInsensitive)")
+}
+
+func run(pass *analysis.Pass) (_ any, err error) {
+	v := newValidator()
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector) //nolint:forcetypeassert // guaranteed
+
+	insp.Preorder([]ast.Node{(*ast.StructType)(nil)}, func(n ast.Node) {
+		for _, f := range n.(*ast.StructType).Fields.List { //nolint:forcetypeassert // filtered above
+			checkField(pass, v, f)
+		}
+	})
+
+	return
+}
+
+func checkField(pass *analysis.Pass, v *vali.Validator, f *ast.Field) {
+	if f.Tag == nil {
+		return
+	}
+
+	raw, err := strconv.Unquote(f.Tag.Value)
+	if err != nil {
+		return
+	}
+
+	var (
+		name, tag string
+		ok        bool
+	)
+
+	for _, name = range tagNames() {
+		if tag, ok = reflect.StructTag(raw).Lookup(name); ok {
+			break
+		}
+	}
+
+	if !ok || tag == "-" {
+		return
+	}
+
+	if err = v.CheckTag(tag); err != nil {
+		pass.Reportf(f.Tag.Pos(), "invalid %s tag: %v", name, err)
+	}
+
+	if !unexported {
+		return
+	}
+
+	for _, field := range f.Names {
+		if !field.IsExported() {
+			pass.Reportf(field.Pos(), "%s tag on unexported field %s", name, field.Name)
+		}
+	}
+}
+
+// newValidator returns a validator that knows about the custom checkers
+// (as no-ops, only their names matter) on top of the builtin ones.
+func newValidator() (v *vali.Validator) {
+	v = vali.New(tagNames()...)
+	v.CaseInsensitive = caseInsensitive
+
+	for name := range strings.SplitSeq(checkers, ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+
+		v.RegisterChecker(name, noop)
+		v.RegisterCheckerMaker(name, func(string) (vali.Checker, error) { return noop, nil })
+	}
+
+	return
+}
+
+func noop(reflect.Value) error {
+	return nil
+}
+
+// tagNames returns the (trimmed) names of the -tag flag.
+func tagNames() (names []string) {
+	for name := range strings.SplitSeq(tagName, ",") {
+		names = append(names, strings.TrimSpace(name))
+	}
+
+	return
+}
@@ -0,0 +1,31 @@
+package valivet_test
+
+import (
+	"testing"
+
+	"github.com/alexaandru/vali/valivet"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	if err := valivet.Analyzer.Flags.Set("checkers", "admin_role, custom_of"); err != nil {
+		t.Fatal(err)
+	}
+
+	analysistest.Run(t, analysistest.TestData(), valivet.Analyzer, "a")
+}
+
+func TestAnalyzerOptions(t *testing.T) {
+	for name, val := range map[string]string{"case-insensitive": "true", "tag": "validate, binding"} {
+		if err := valivet.Analyzer.Flags.Set(name, val); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Cleanup(func() {
+		_ = valivet.Analyzer.Flags.Set("case-insensitive", "false")
+		_ = valivet.Analyzer.Flags.Set("tag", "validate")
+	})
+
+	analysistest.Run(t, analysistest.TestData(), valivet.Analyzer, "b")
+}
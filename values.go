@@ -0,0 +1,171 @@
+package vali
+
+import (
+	"encoding"
+	"fmt"
+	"maps"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ValuesTagNames holds the struct tags (in order of precedence) used by
+// [Validator.DecodeValues] to map fields to form/query parameters.
+var ValuesTagNames = []string{"form", "query"}
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// ValidateValues validates vals against [DefaultValidator].
+// See [Validator.ValidateValues] for details.
+func ValidateValues(vals url.Values, rules map[string]string) error {
+	return DefaultValidator.ValidateValues(vals, rules)
+}
+
+// ValidateValues validates form or query parameters, using rules
+// (parameter name -> tag). Every value of a parameter is validated;
+// a missing parameter is validated as an empty string.
+func (v *Validator) ValidateValues(vals url.Values, rules map[string]string) (err error) {
+	st := &state{}
+
+	for _, key := range slices.Sorted(maps.Keys(rules)) {
+		vx := vals[key]
+		if len(vx) == 0 {
+			vx = []string{""}
+		}
+
+		leave := st.enter(key)
+
+		for _, x := range vx {
+			if err = v.validate(st, reflect.ValueOf(x), rules[key], key); err != nil {
+				return v.observe(reflect.ValueOf(vals), st.collected(err))
+			}
+		}
+
+		leave()
+	}
+
+	return v.observe(reflect.ValueOf(vals), st.collected(nil))
+}
+
+// DecodeValues decodes and validates vals against [DefaultValidator].
+// See [Validator.DecodeValues] for details.
+func DecodeValues(vals url.Values, dst any) error {
+	return DefaultValidator.DecodeValues(vals, dst)
+}
+
+// DecodeValues decodes form or query parameters into dst (a pointer to
+// a struct) and then validates it. Parameters are mapped to fields by
+// their `form` or `query` tags (see [ValuesTagNames]) or, lacking those,
+// by their names. Fields can be strings, bools, numbers, types implementing
+// [encoding.TextUnmarshaler], pointers to or slices of any of those.
+func (v *Validator) DecodeValues(vals url.Values, dst any) (err error) {
+	ref := reflect.ValueOf(dst)
+	if ref.Kind() != reflect.Pointer || ref.IsNil() || ref.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("cannot decode into %T, need a pointer to a struct", dst)
+	}
+
+	if err = decodeValues(vals, ref.Elem()); err != nil {
+		return
+	}
+
+	return v.Validate(dst)
+}
+
+func decodeValues(vals url.Values, dst reflect.Value) (err error) {
+	t := dst.Type()
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name := valuesName(f)
+		if name == "-" {
+			continue
+		}
+
+		vx, ok := vals[name]
+		if !ok || len(vx) == 0 {
+			continue
+		}
+
+		if err = setValues(dst.Field(i), vx); err != nil {
+			return fmt.Errorf("%s: %w", f.Name, err)
+		}
+	}
+
+	return
+}
+
+func valuesName(f reflect.StructField) string {
+	for _, tag := range ValuesTagNames {
+		if name, _, _ := strings.Cut(f.Tag.Get(tag), ","); name != "" {
+			return name
+		}
+	}
+
+	return f.Name
+}
+
+func setValues(dst reflect.Value, vx []string) (err error) {
+	if dst.Kind() == reflect.Slice && !reflect.PointerTo(dst.Type()).Implements(textUnmarshalerType) {
+		sl := reflect.MakeSlice(dst.Type(), len(vx), len(vx))
+		for i, x := range vx {
+			if err = setValue(sl.Index(i), x); err != nil {
+				return
+			}
+		}
+
+		dst.Set(sl)
+
+		return
+	}
+
+	return setValue(dst, vx[0])
+}
+
+func setValue(dst reflect.Value, x string) (err error) {
+	if dst.Kind() == reflect.Pointer {
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+
+		return setValue(dst.Elem(), x)
+	}
+
+	if tu, ok := dst.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return tu.UnmarshalText([]byte(x))
+	}
+
+	switch dst.Kind() { //nolint:exhaustive // the rest are unsupported
+	case reflect.String:
+		dst.SetString(x)
+	case reflect.Bool:
+		var b bool
+		if b, err = strconv.ParseBool(x); err == nil {
+			dst.SetBool(b)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		if n, err = strconv.ParseInt(x, 10, dst.Type().Bits()); err == nil {
+			dst.SetInt(n)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var n uint64
+		if n, err = strconv.ParseUint(x, 10, dst.Type().Bits()); err == nil {
+			dst.SetUint(n)
+		}
+	case reflect.Float32, reflect.Float64:
+		var n float64
+		if n, err = strconv.ParseFloat(x, dst.Type().Bits()); err == nil {
+			dst.SetFloat(n)
+		}
+	default:
+		return fmt.Errorf("unsupported kind %s", dst.Kind())
+	}
+
+	return
+}
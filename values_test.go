@@ -0,0 +1,95 @@
+package vali
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type search struct {
+	Query   string    `form:"q"      validate:"required,min:2"`
+	Page    int       `query:"page"  validate:"min:1"`
+	Sizes   []uint8   `form:"size"   validate:"max:3"`
+	Exact   *bool     `form:"exact"`
+	Since   time.Time `form:"since"`
+	Ratio   float32   `form:"ratio"`
+	Sort    string
+	Ignored string `form:"-"`
+	private string
+}
+
+func TestValidateValues(t *testing.T) {
+	t.Parallel()
+
+	rules := map[string]string{"q": "required,min:2", "page": "numeric", "tag": "alpha"}
+
+	testCases := []struct {
+		vals url.Values
+		exp  string
+	}{
+		{url.Values{"q": {"go"}, "page": {"2"}, "tag": {"a", "b"}}, ""},
+		{url.Values{"q": {"go"}}, ""},
+		{url.Values{}, "q: required check failed: value missing"},
+		{url.Values{"q": {"g"}}, "q: min check failed: len 1 is less than 2"},
+		{url.Values{"q": {"go"}, "page": {"x"}}, `page: numeric check failed: "x" does not match ^\d*$`},
+		{url.Values{"q": {"go"}, "tag": {"a", "1"}}, `tag: alpha check failed: "1" does not match (?i)^[a-z]*$`},
+	}
+
+	for _, tc := range testCases {
+		if act := errString(ValidateValues(tc.vals, rules)); act != tc.exp {
+			t.Fatalf("Expected %q got %q for %v", tc.exp, act, tc.vals)
+		}
+	}
+}
+
+func TestDecodeValues(t *testing.T) {
+	t.Parallel()
+
+	vals, err := url.ParseQuery("q=golang&page=3&size=1&size=2&exact=true&since=2024-01-02T03:04:05Z&ratio=0.5&Sort=asc&Ignored=x&private=x")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var s search
+	if err = DecodeValues(vals, &s); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Query != "golang" || s.Page != 3 || len(s.Sizes) != 2 || s.Sizes[1] != 2 || s.Exact == nil || !*s.Exact ||
+		s.Since.Year() != 2024 || s.Ratio != 0.5 || s.Sort != "asc" || s.Ignored != "" || s.private != "" {
+		t.Fatalf("Unexpected decoded value %#v", s)
+	}
+}
+
+func TestDecodeValuesErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		vals   url.Values
+		dst    any
+		expErr error
+		exp    string
+	}{
+		{url.Values{"q": {"go"}, "page": {"0"}}, &search{}, ErrCheckFailed, "Page: min check failed: 0 is less than 1"},
+		{url.Values{"q": {"go"}, "page": {"1"}, "size": {"1", "2", "3", "4"}}, &search{}, ErrCheckFailed, "Sizes: max check failed: len 4 is more than 3"},
+		{url.Values{"q": {"go"}, "page": {"x"}}, &search{}, nil, `Page: strconv.ParseInt: parsing "x": invalid syntax`},
+		{url.Values{"q": {"go"}, "size": {"256"}}, &search{}, nil, `Sizes: strconv.ParseUint: parsing "256": value out of range`},
+		{url.Values{"q": {"go"}, "exact": {"maybe"}}, &search{}, nil, `Exact: strconv.ParseBool: parsing "maybe": invalid syntax`},
+		{url.Values{"q": {"go"}, "since": {"yesterday"}}, &search{}, nil, `Since: parsing time "yesterday" as "2006-01-02T15:04:05Z07:00": cannot parse "yesterday" as "2006"`},
+		{url.Values{"C": {"x"}}, &struct{ C chan int }{}, nil, "C: unsupported kind chan"},
+		{url.Values{}, search{}, nil, "cannot decode into vali.search, need a pointer to a struct"},
+		{url.Values{}, (*search)(nil), nil, "cannot decode into *vali.search, need a pointer to a struct"},
+	}
+
+	for _, tc := range testCases {
+		err := DecodeValues(tc.vals, tc.dst)
+		if tc.expErr != nil && !errors.Is(err, tc.expErr) {
+			t.Fatalf("Expected %v got %v", tc.expErr, err)
+		}
+
+		if act := errString(err); act != tc.exp {
+			t.Fatalf("Expected %q got %q", tc.exp, act)
+		}
+	}
+}